@@ -0,0 +1,63 @@
+package jsonschema
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+// SetAudience restricts generated schemas to the fields, enum values and
+// messages visible to audience. Elements without audience labels are visible
+// to every audience; an empty audience emits everything.
+func (g *Generator) SetAudience(audience string) {
+	g.audience = audience
+}
+
+// Audience returns the audience selected by SetAudience
+func (g *Generator) Audience() string {
+	return g.audience
+}
+
+// isFieldVisible reports whether field appears in generated schemas: it must
+// not be hidden, and both the field and, for message fields, its message type
+// must be visible to the generator's audience
+func (g *Generator) isFieldVisible(field protoreflect.FieldDescriptor) bool {
+	fieldOpts := field.Options().(*descriptorpb.FieldOptions)
+	if g.isFieldHidden(fieldOpts) || !g.isAudienceVisible(fieldOpts, jsonschemapb.E_Audience) {
+		return false
+	}
+	if field.Kind() == protoreflect.MessageKind && !field.IsMap() {
+		return g.isAudienceVisible(field.Message().Options(), jsonschemapb.E_MessageAudience)
+	}
+	return true
+}
+
+// isAudienceVisible reports whether the audience labels held by ext on opts
+// admit the generator's audience
+func (g *Generator) isAudienceVisible(opts proto.Message, ext protoreflect.ExtensionType) bool {
+	if g.audience == "" || !proto.HasExtension(opts, ext) {
+		return true
+	}
+	for _, audience := range proto.GetExtension(opts, ext).([]string) {
+		if audience == g.audience {
+			return true
+		}
+	}
+	return false
+}
+
+// enumValueNames lists the names of the values of enum visible to the
+// generator's audience
+func (g *Generator) enumValueNames(enum protoreflect.EnumDescriptor) []string {
+	names := []string{}
+	values := enum.Values()
+	for i := 0; i < values.Len(); i++ {
+		value := values.Get(i)
+		if g.isAudienceVisible(value.Options(), jsonschemapb.E_EnumValueAudience) {
+			names = append(names, string(value.Name()))
+		}
+	}
+	return names
+}
@@ -0,0 +1,142 @@
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+// audienceFile builds an Account message whose notes field, ROLE_ROOT enum
+// value and Secret message type carry audience labels
+func audienceFile(t *testing.T) protoreflect.FileDescriptor {
+	t.Helper()
+	rootOpts := &descriptorpb.EnumValueOptions{}
+	proto.SetExtension(rootOpts, jsonschemapb.E_EnumValueAudience, []string{"admin"})
+	return testFileWithEnums(t,
+		[]*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Role"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("ROLE_UNSPECIFIED"), Number: proto.Int32(0)},
+				{Name: proto.String("ROLE_USER"), Number: proto.Int32(1)},
+				{Name: proto.String("ROLE_ROOT"), Number: proto.Int32(2), Options: rootOpts},
+			},
+		}},
+		testMessage("Secret", msgOpts(ext(jsonschemapb.E_MessageAudience, []string{"admin"})),
+			testField("value", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+		),
+		testMessage("Account", nil,
+			testField("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+			&descriptorpb.FieldDescriptorProto{
+				Name:     proto.String("role"),
+				Number:   proto.Int32(2),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_ENUM.Enum(),
+				TypeName: proto.String(".test.Role"),
+			},
+			testField("notes", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(ext(jsonschemapb.E_Audience, []string{"admin", "support"}))),
+			testMessageField("secret", 4, ".test.Secret", nil),
+		),
+	)
+}
+
+func TestAudience_Visibility(t *testing.T) {
+	fd := audienceFile(t)
+
+	tests := []struct {
+		audience   string
+		properties []string
+		roles      []string
+	}{
+		{"", []string{"name", "notes", "role", "secret"}, []string{"ROLE_UNSPECIFIED", "ROLE_USER", "ROLE_ROOT"}},
+		{"admin", []string{"name", "notes", "role", "secret"}, []string{"ROLE_UNSPECIFIED", "ROLE_USER", "ROLE_ROOT"}},
+		{"support", []string{"name", "notes", "role"}, []string{"ROLE_UNSPECIFIED", "ROLE_USER"}},
+		{"public", []string{"name", "role"}, []string{"ROLE_UNSPECIFIED", "ROLE_USER"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.audience, func(t *testing.T) {
+			g := NewGenerator()
+			g.SetAudience(tt.audience)
+			schema, err := g.GenerateSchema(fd.Messages().ByName("Account"))
+			if err != nil {
+				t.Fatalf("GenerateSchema failed: %v", err)
+			}
+			props := mustSchemaMap(t, schema)["properties"].(map[string]interface{})
+			var names []string
+			for _, name := range []string{"name", "notes", "role", "secret"} {
+				if _, ok := props[name]; ok {
+					names = append(names, name)
+				}
+			}
+			if !reflect.DeepEqual(names, tt.properties) {
+				t.Errorf("properties = %v, want %v", names, tt.properties)
+			}
+			roles, _ := stringList(props["role"].(map[string]interface{})["enum"])
+			if !reflect.DeepEqual(roles, tt.roles) {
+				t.Errorf("role enum = %v, want %v", roles, tt.roles)
+			}
+		})
+	}
+}
+
+func TestAudience_HiddenMessage(t *testing.T) {
+	fd := audienceFile(t)
+	g := NewGenerator()
+	g.SetAudience("public")
+
+	schema, err := g.GenerateSchema(fd.Messages().ByName("Secret"))
+	if err != nil || schema != nil {
+		t.Errorf("expected no schema for a message outside the audience, got %v, %v", schema, err)
+	}
+	ordered, err := g.GenerateOrderedSchema(fd.Messages().ByName("Secret"))
+	if err != nil || ordered != nil {
+		t.Errorf("expected no ordered schema for a message outside the audience, got %v, %v", ordered, err)
+	}
+}
+
+func TestAudience_ConditionalAcrossAudiences(t *testing.T) {
+	adminOnly := fieldOpts(ext(jsonschemapb.E_Audience, []string{"admin"}))
+	fd := testFile(t, testMessage("Ticket", msgOpts(
+		ext(jsonschemapb.E_DependentRequired, []*jsonschemapb.DependentRequired{
+			{Field: "email", Requires: []string{"name", "escalation"}},
+			{Field: "escalation", Requires: []string{"name"}},
+		}),
+		ext(jsonschemapb.E_Conditional, []*jsonschemapb.ConditionalRule{
+			{Field: "priority", Equals: "high", ThenRequired: []string{"email"}},
+			{Field: "name", Equals: "root", ThenRequired: []string{"escalation"}},
+		}),
+	),
+		testField("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+		testField("email", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+		testField("priority", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, adminOnly),
+		testField("escalation", 4, descriptorpb.FieldDescriptorProto_TYPE_STRING, adminOnly),
+	))
+
+	g := NewGenerator()
+	g.SetAudience("public")
+	schema, err := g.GenerateSchema(fd.Messages().ByName("Ticket"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	m := mustSchemaMap(t, schema)
+	if !reflect.DeepEqual(m["dependentRequired"], map[string]interface{}{"email": []interface{}{"name"}}) {
+		t.Errorf("dependentRequired = %v, want fields outside the audience dropped", m["dependentRequired"])
+	}
+	for _, keyword := range []string{"if", "then", "allOf"} {
+		if _, ok := m[keyword]; ok {
+			t.Errorf("expected conditionals on fields outside the audience to be skipped, got %s: %v", keyword, m[keyword])
+		}
+	}
+
+	g.SetAudience("admin")
+	schema, err = g.GenerateSchema(fd.Messages().ByName("Ticket"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	if allOf, _ := mustSchemaMap(t, schema)["allOf"].([]interface{}); len(allOf) != 2 {
+		t.Errorf("expected both conditionals for the admin audience, got %v", allOf)
+	}
+}
@@ -66,3 +66,191 @@ func TestGenerateGoogleSchemaLiteral_PropertiesSorted(t *testing.T) {
 		t.Errorf("properties must be sorted alpha<mango<zebra, got positions %d,%d,%d", ia, im, iz)
 	}
 }
+
+func TestGenerateGoogleSchemaLiteral_ObjectConstraints(t *testing.T) {
+	m := map[string]interface{}{
+		"type":                 "object",
+		"additionalProperties": true,
+		"minProperties":        float64(1),
+		"maxProperties":        float64(3),
+	}
+	out := generateGoogleSchemaLiteral(m, 0)
+	for _, want := range []string{
+		"AdditionalProperties: &jsonschema.Schema{},",
+		"MinProperties: &[]int{1}[0],",
+		"MaxProperties: &[]int{3}[0],",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q\n--- got ---\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerateGoogleSchemaLiteral_MessageExamples(t *testing.T) {
+	m := map[string]interface{}{
+		"type": "object",
+		"examples": []interface{}{
+			map[string]interface{}{"query": "golang", "limit": float64(10), "tags": []interface{}{"a", true, nil}},
+		},
+	}
+	out := generateGoogleSchemaLiteral(m, 0)
+	want := `Examples: []any{map[string]any{"limit": float64(10), "query": "golang", "tags": []any{"a", true, nil}}},`
+	if !strings.Contains(out, want) {
+		t.Errorf("expected output to contain %q\n--- got ---\n%s", want, out)
+	}
+}
+
+func TestGenerateGoogleSchemaLiteral_Conditionals(t *testing.T) {
+	m := map[string]interface{}{
+		"type":              "object",
+		"dependentRequired": map[string]interface{}{"endDate": []interface{}{"startDate"}},
+		"dependentSchemas": map[string]interface{}{
+			"end_date": map[string]interface{}{"anyOf": []interface{}{
+				map[string]interface{}{"required": []interface{}{"startDate"}},
+				map[string]interface{}{"required": []interface{}{"start_date"}},
+			}},
+		},
+		"allOf": []interface{}{
+			map[string]interface{}{
+				"if": map[string]interface{}{
+					"properties": map[string]interface{}{"method": map[string]interface{}{"const": "EXPRESS"}},
+					"required":   []interface{}{"method"},
+				},
+				"then": map[string]interface{}{"required": []interface{}{"phone"}},
+			},
+		},
+	}
+	out := generateGoogleSchemaLiteral(m, 0)
+	for _, want := range []string{
+		`"endDate": {"startDate"},`,
+		"DependentSchemas: map[string]*jsonschema.Schema{",
+		`"end_date": &jsonschema.Schema{`,
+		"AllOf: []*jsonschema.Schema{",
+		"If: &jsonschema.Schema{",
+		`Const: &[]any{"EXPRESS"}[0],`,
+		"Then: &jsonschema.Schema{",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q\n--- got ---\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerateGoogleSchemaLiteral_VendorExtensions(t *testing.T) {
+	m := map[string]interface{}{
+		"type":       "object",
+		"x-ui-group": map[string]interface{}{"order": float64(2), "label": "Contact"},
+		"properties": map[string]interface{}{
+			"password": map[string]interface{}{"type": "string", "x-sensitive": true},
+		},
+	}
+	out := generateGoogleSchemaLiteral(m, 0)
+	for _, want := range []string{
+		`Extra: map[string]any{"x-ui-group": map[string]any{"label": "Contact", "order": float64(2)}},`,
+		`Extra: map[string]any{"x-sensitive": true},`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q\n--- got ---\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerateGoogleSchemaLiteral_ValidationKeywords(t *testing.T) {
+	m := map[string]interface{}{
+		"type":             "integer",
+		"readOnly":         true,
+		"exclusiveMinimum": float64(0),
+		"exclusiveMaximum": float64(10),
+		"enum":             []interface{}{float64(1), float64(2)},
+		"not":              map[string]interface{}{"enum": []interface{}{float64(7)}},
+		"items":            map[string]interface{}{"type": "string"},
+		"minItems":         float64(1),
+		"maxItems":         float64(5),
+		"uniqueItems":      true,
+	}
+	out := generateGoogleSchemaLiteral(m, 0)
+	for _, want := range []string{
+		"ReadOnly: true,",
+		"ExclusiveMinimum: &[]float64{0}[0],",
+		"ExclusiveMaximum: &[]float64{10}[0],",
+		"Enum: []any{float64(1), float64(2)},",
+		"Not: &jsonschema.Schema{",
+		"Enum: []any{float64(7)},",
+		"MinItems: &[]int{1}[0],",
+		"MaxItems: &[]int{5}[0],",
+		"UniqueItems: true,",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q\n--- got ---\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerateGoogleSchemaLiteral_Deprecated(t *testing.T) {
+	m := map[string]interface{}{
+		"type":       "string",
+		"title":      "Nickname",
+		"deprecated": true,
+		"x-since":    "v1.2",
+	}
+	out := generateGoogleSchemaLiteral(m, 0)
+	for _, want := range []string{
+		`Title: "Nickname",`,
+		"Deprecated: true,",
+		`Extra: map[string]any{"x-since": "v1.2"},`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q\n--- got ---\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerateGoogleSchemaLiteral_DialectKeywords(t *testing.T) {
+	m := map[string]interface{}{
+		"$schema": "http://json-schema.org/draft-04/schema#",
+		"type":    "object",
+		"properties": map[string]interface{}{
+			"blob": map[string]interface{}{"type": "string", "contentEncoding": "base64"},
+		},
+		"dependencies": map[string]interface{}{"phone": []interface{}{"name"}},
+		"anyOf": []interface{}{
+			map[string]interface{}{"required": []interface{}{"phone"}},
+		},
+	}
+	out := generateGoogleSchemaLiteral(m, 0)
+	for _, want := range []string{
+		`Schema: "http://json-schema.org/draft-04/schema#",`,
+		`ContentEncoding: "base64",`,
+		"DependencyStrings: map[string][]string{",
+		`"phone": {"name"},`,
+		"AnyOf: []*jsonschema.Schema{",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q\n--- got ---\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerateGoogleSchemaLiteral_NullableTypes(t *testing.T) {
+	m := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"status": map[string]interface{}{
+				"type": []interface{}{"string", "null"},
+				"enum": []interface{}{"OPEN", nil},
+			},
+		},
+	}
+	out := generateGoogleSchemaLiteral(m, 0)
+	for _, want := range []string{
+		`Types: []string{"string", "null"},`,
+		`Enum: []any{"OPEN", nil},`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q\n--- got ---\n%s", want, out)
+		}
+	}
+	if literal := generateSchemaLiteral(m, 0); !strings.Contains(literal, "\tnil,\n") {
+		t.Errorf("expected null enum value as nil\n--- got ---\n%s", literal)
+	}
+}
@@ -7,10 +7,13 @@ import (
 	"io"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/descriptorpb"
 	"google.golang.org/protobuf/types/pluginpb"
 
@@ -70,17 +73,32 @@ func main() {
 }
 
 type genParams struct {
-	format        string // "json" or "go_const"
-	suffix        string // file suffix for go_const format
-	preserveOrder bool   // preserve field order from proto definition
-	schemaStruct  bool   // generate jsonschema.Schema struct literal (map[string]interface{})
-	googleSchema  bool   // generate Google jsonschema.Schema struct literal (*jsonschema.Schema)
+	format        string   // "json", "go_const", "openapi", "mcp_tools" or "tools"
+	suffix        string   // file suffix for go_const format
+	preserveOrder bool     // preserve field order from proto definition
+	schemaStruct  bool     // generate jsonschema.Schema struct literal (map[string]interface{})
+	googleSchema  bool     // generate Google jsonschema.Schema struct literal (*jsonschema.Schema)
+	partial       bool     // generate every message as a partial (update) schema
+	strictObjects bool     // default message schemas to additionalProperties: false
+	typeMap       string   // path of a JSON file mapping message full names to schemas
+	pgv           bool     // translate protoc-gen-validate rules
+	openapiv2     bool     // fall back to grpc-gateway openapiv2 annotations
+	locale        string   // locale of titles and descriptions
+	locales       []string // extra locales rendered by GetJSONSchemaFor in go_const format
+	audience      string   // audience whose visible fields are emitted
+	audiences     []string // audiences given their own accessor in go_const format
+	naming        string   // property naming strategy: json_name, proto_name or both
+	dialect       string   // JSON Schema dialect: draft-04, draft-07, 2019-09 or 2020-12
+	openapi       string   // OpenAPI version of the openapi format: 3.0 or 3.1
+	profile       string   // profile rewriting schemas for a consumer: openai_strict, gemini or mcp_elicitation
+	provider      string   // tool definition format of the tools format: anthropic, openai or gemini
 }
 
 func parseParameters(param string) genParams {
 	params := genParams{
-		format: "json",
-		suffix: "_jsonschema",
+		format:  "json",
+		suffix:  "_jsonschema",
+		openapi: string(jsonschema.OpenAPI31),
 	}
 
 	if param == "" {
@@ -107,15 +125,97 @@ func parseParameters(param string) genParams {
 			params.schemaStruct = value == "true"
 		case "google_schema":
 			params.googleSchema = value == "true"
+		case "partial":
+			params.partial = value == "true"
+		case "strict_objects":
+			params.strictObjects = value == "true"
+		case "type_map":
+			params.typeMap = value
+		case "pgv":
+			params.pgv = value == "true"
+		case "openapiv2":
+			params.openapiv2 = value == "true"
+		case "locale":
+			params.locale = value
+		case "locales":
+			params.locales = splitList(value)
+		case "audience":
+			params.audience = value
+		case "audiences":
+			params.audiences = splitList(value)
+		case "naming":
+			params.naming = value
+		case "dialect":
+			params.dialect = value
+		case "openapi_version":
+			params.openapi = value
+		case "profile":
+			params.profile = value
+		case "provider":
+			params.provider = value
 		}
 	}
 
 	return params
 }
 
+// splitList splits a list parameter value, which is ":"-separated since ","
+// already separates parameters
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ":") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func generate(plugin *protogen.Plugin, params genParams) error {
 	gen := jsonschema.NewGenerator()
 	gen.SetPreserveOrder(params.preserveOrder)
+	gen.SetPartial(params.partial)
+	gen.SetStrictObjects(params.strictObjects)
+	gen.SetPGV(params.pgv)
+	gen.SetOpenAPIv2(params.openapiv2)
+	gen.SetLocale(params.locale)
+	gen.SetAudience(params.audience)
+	if params.naming != "" {
+		naming := jsonschema.NamingStrategy(params.naming)
+		if !naming.IsValid() {
+			return fmt.Errorf("unknown naming strategy: %s", params.naming)
+		}
+		gen.SetNamingStrategy(naming)
+	}
+	if params.dialect != "" {
+		dialect := jsonschema.Dialect(params.dialect)
+		if !dialect.IsValid() {
+			return fmt.Errorf("unknown dialect: %s", params.dialect)
+		}
+		gen.SetDialect(dialect)
+	}
+	if params.profile != "" {
+		profile := jsonschema.Profile(params.profile)
+		if !profile.IsValid() {
+			return fmt.Errorf("unknown profile: %s", params.profile)
+		}
+		gen.SetProfile(profile)
+	}
+	// profiles report what they drop; protoc relays plugin stderr to the user
+	gen.SetWarningHandler(func(md protoreflect.MessageDescriptor, warning string) {
+		fmt.Fprintf(os.Stderr, "protoc-gen-jsonschema: %s: %s\n", md.FullName(), warning)
+	})
+	if err := checkAudienceSuffixes(params.audiences, len(params.locales) > 0); err != nil {
+		return err
+	}
+	if params.format == "tools" && !jsonschema.ToolProvider(params.provider).IsValid() {
+		return fmt.Errorf("format=tools requires provider=anthropic, openai or gemini, got %q", params.provider)
+	}
+	if params.typeMap != "" {
+		if err := registerTypeMap(gen, params.typeMap); err != nil {
+			return err
+		}
+	}
 
 	for _, file := range plugin.Files {
 		if !file.Generate {
@@ -132,6 +232,18 @@ func generate(plugin *protogen.Plugin, params genParams) error {
 			if err := generateSchemaFile(plugin, gen, file); err != nil {
 				return fmt.Errorf("failed to generate schema for %s: %w", file.Desc.Path(), err)
 			}
+		case "openapi":
+			if err := generateOpenAPIFile(plugin, gen, file, params); err != nil {
+				return fmt.Errorf("failed to generate OpenAPI document for %s: %w", file.Desc.Path(), err)
+			}
+		case "mcp_tools":
+			if err := generateMCPToolsFile(plugin, gen, file); err != nil {
+				return fmt.Errorf("failed to generate MCP tools for %s: %w", file.Desc.Path(), err)
+			}
+		case "tools":
+			if err := generateToolsFile(plugin, gen, file, params); err != nil {
+				return fmt.Errorf("failed to generate tools for %s: %w", file.Desc.Path(), err)
+			}
 		default:
 			return fmt.Errorf("unknown format: %s", params.format)
 		}
@@ -140,6 +252,21 @@ func generate(plugin *protogen.Plugin, params genParams) error {
 	return nil
 }
 
+// registerTypeMap registers the declarative type map file at path (relative to
+// the directory protoc runs in) on gen
+func registerTypeMap(gen *jsonschema.Generator, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open type_map: %w", err)
+	}
+	defer f.Close()
+
+	if err := gen.RegisterTypeMap(f); err != nil {
+		return fmt.Errorf("type_map %s: %w", path, err)
+	}
+	return nil
+}
+
 func generateGoConstFile(plugin *protogen.Plugin, gen *jsonschema.Generator, file *protogen.File, params genParams) error {
 	if len(file.Messages) == 0 {
 		return nil
@@ -155,9 +282,12 @@ func generateGoConstFile(plugin *protogen.Plugin, gen *jsonschema.Generator, fil
 	g.P()
 	g.P("package ", file.GoPackageName)
 	g.P()
-	if params.schemaStruct || params.googleSchema {
+	if params.schemaStruct || params.googleSchema || len(params.locales) > 0 {
 		g.P("import (")
 		g.P("\t\"encoding/json\"")
+		if len(params.locales) > 0 {
+			g.P("\t\"strings\"")
+		}
 		g.P()
 		if params.schemaStruct {
 			g.P("\tlocalschema \"github.com/sunerpy/protoc-gen-jsonschema\"")
@@ -174,7 +304,7 @@ func generateGoConstFile(plugin *protogen.Plugin, gen *jsonschema.Generator, fil
 	// Generate methods and constants for each message
 	for _, message := range file.Messages {
 		if shouldGenerateSchema(message) {
-			if err := generateMessageConst(g, gen, message, params.schemaStruct, params.googleSchema); err != nil {
+			if err := generateMessageConst(g, gen, message, params); err != nil {
 				return fmt.Errorf("failed to generate const for %s: %w", message.Desc.FullName(), err)
 			}
 		}
@@ -183,36 +313,12 @@ func generateGoConstFile(plugin *protogen.Plugin, gen *jsonschema.Generator, fil
 	return nil
 }
 
-func generateMessageConst(g *protogen.GeneratedFile, gen *jsonschema.Generator, message *protogen.Message, schemaStruct, googleSchema bool) error {
-	var jsonStr string
+func generateMessageConst(g *protogen.GeneratedFile, gen *jsonschema.Generator, message *protogen.Message, params genParams) error {
+	schemaStruct, googleSchema := params.schemaStruct, params.googleSchema
 
-	// Generate JSON Schema based on preserveOrder setting
-	if gen.IsPreserveOrder() {
-		orderedSchema, err := gen.GenerateOrderedSchema(message.Desc)
-		if err != nil {
-			return err
-		}
-		if orderedSchema == nil {
-			return nil
-		}
-		jsonBytes, err := json.Marshal(orderedSchema)
-		if err != nil {
-			return fmt.Errorf("failed to marshal ordered schema: %w", err)
-		}
-		jsonStr = string(jsonBytes)
-	} else {
-		schema, err := gen.GenerateSchema(message.Desc)
-		if err != nil {
-			return err
-		}
-		if schema == nil {
-			return nil
-		}
-		jsonBytes, err := json.Marshal(schema)
-		if err != nil {
-			return fmt.Errorf("failed to marshal schema: %w", err)
-		}
-		jsonStr = string(jsonBytes)
+	jsonStr, err := schemaJSON(gen, message)
+	if err != nil || jsonStr == "" {
+		return err
 	}
 
 	// Constant name: UserRequest -> userRequestSchemaJSON
@@ -264,6 +370,17 @@ func generateMessageConst(g *protogen.GeneratedFile, gen *jsonschema.Generator,
 	g.P("const ", constName, " = `", jsonStr, "`")
 	g.P()
 
+	if len(params.locales) > 0 {
+		if err := generateLocalizedConsts(g, gen, message, constName, params.locales); err != nil {
+			return err
+		}
+	}
+	if len(params.audiences) > 0 {
+		if err := generateAudienceConsts(g, gen, message, constName, params.audiences); err != nil {
+			return err
+		}
+	}
+
 	// Generate localschema.Schema struct literal if requested
 	if schemaStruct {
 		varName := toLowerCamelCase(message.GoIdent.GoName) + "Schema"
@@ -323,6 +440,147 @@ func generateMessageConst(g *protogen.GeneratedFile, gen *jsonschema.Generator,
 	return nil
 }
 
+// schemaJSON renders the schema of message as compact JSON, honoring the
+// generator's preserveOrder setting. It returns "" when no schema is generated.
+func schemaJSON(gen *jsonschema.Generator, message *protogen.Message) (string, error) {
+	if gen.IsPreserveOrder() {
+		orderedSchema, err := gen.GenerateOrderedSchema(message.Desc)
+		if err != nil || orderedSchema == nil {
+			return "", err
+		}
+		jsonBytes, err := json.Marshal(orderedSchema)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal ordered schema: %w", err)
+		}
+		return string(jsonBytes), nil
+	}
+
+	schema, err := gen.GenerateSchema(message.Desc)
+	if err != nil || schema == nil {
+		return "", err
+	}
+	jsonBytes, err := json.Marshal(schema)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal schema: %w", err)
+	}
+	return string(jsonBytes), nil
+}
+
+// generateLocalizedConsts emits a map of the schema of message rendered in
+// each of locales, and a GetJSONSchemaFor method that looks a locale up with
+// the same fallback chain as the generator, returning constName when nothing
+// matches
+func generateLocalizedConsts(g *protogen.GeneratedFile, gen *jsonschema.Generator, message *protogen.Message, constName string, locales []string) error {
+	mapName := constName + "ByLocale"
+	typeName := message.GoIdent.GoName
+
+	defaultLocale := gen.Locale()
+	defer gen.SetLocale(defaultLocale)
+
+	g.P("var ", mapName, " = map[string]string{")
+	for _, locale := range locales {
+		gen.SetLocale(locale)
+		jsonStr, err := schemaJSON(gen, message)
+		if err != nil {
+			return fmt.Errorf("locale %s: %w", locale, err)
+		}
+		g.P("\t", strconv.Quote(strings.ToLower(strings.ReplaceAll(locale, "_", "-"))), ": `", jsonStr, "`,")
+	}
+	g.P("}")
+	g.P()
+
+	g.P("// GetJSONSchemaFor returns the JSON Schema for ", typeName, " with titles and")
+	g.P("// descriptions in the given locale, falling back from e.g. \"zh-Hant-TW\" to")
+	g.P("// \"zh-Hant\" and \"zh\", and to GetJSONSchema() when no locale matches")
+	g.P("func (*", typeName, ") GetJSONSchemaFor(locale string) string {")
+	g.P("\tlocale = strings.ToLower(strings.ReplaceAll(locale, \"_\", \"-\"))")
+	g.P("\tfor locale != \"\" {")
+	g.P("\t\tif schema, ok := ", mapName, "[locale]; ok {")
+	g.P("\t\t\treturn schema")
+	g.P("\t\t}")
+	g.P("\t\ti := strings.LastIndexByte(locale, '-')")
+	g.P("\t\tif i < 0 {")
+	g.P("\t\t\tbreak")
+	g.P("\t\t}")
+	g.P("\t\tlocale = locale[:i]")
+	g.P("\t}")
+	g.P("\treturn ", constName)
+	g.P("}")
+	g.P()
+	return nil
+}
+
+// generateAudienceConsts emits, for each of audiences, a constant holding the
+// schema of message restricted to that audience and a GetJSONSchemaFor<Audience>
+// accessor returning it
+func generateAudienceConsts(g *protogen.GeneratedFile, gen *jsonschema.Generator, message *protogen.Message, constName string, audiences []string) error {
+	typeName := message.GoIdent.GoName
+
+	defaultAudience := gen.Audience()
+	defer gen.SetAudience(defaultAudience)
+
+	for _, audience := range audiences {
+		gen.SetAudience(audience)
+		jsonStr, err := schemaJSON(gen, message)
+		if err != nil {
+			return fmt.Errorf("audience %s: %w", audience, err)
+		}
+
+		suffix := exportedName(audience)
+		g.P("// GetJSONSchemaFor", suffix, " returns the JSON Schema for ", typeName, " as seen by")
+		g.P("// the ", strconv.Quote(audience), " audience, or \"\" when the message is not visible to it")
+		g.P("func (*", typeName, ") GetJSONSchemaFor", suffix, "() string {")
+		g.P("\treturn ", constName, suffix)
+		g.P("}")
+		g.P()
+		g.P("const ", constName, suffix, " = `", jsonStr, "`")
+		g.P()
+	}
+	return nil
+}
+
+// checkAudienceSuffixes rejects audiences whose exported suffixes would make
+// generateAudienceConsts declare the same identifier twice: an empty suffix
+// clashes with the base constant and the locale accessor, two audiences may
+// share a suffix, and "ByLocale" clashes with the locale map
+func checkAudienceSuffixes(audiences []string, localized bool) error {
+	seen := make(map[string]string, len(audiences))
+	for _, audience := range audiences {
+		suffix := exportedName(audience)
+		if suffix == "" {
+			return fmt.Errorf("audience %q has no letters or digits to name its accessor", audience)
+		}
+		if other, ok := seen[suffix]; ok {
+			return fmt.Errorf("audiences %q and %q both produce the accessor GetJSONSchemaFor%s", other, audience, suffix)
+		}
+		if localized && suffix == "ByLocale" {
+			return fmt.Errorf("audience %q clashes with the locale map generated for locales", audience)
+		}
+		seen[suffix] = audience
+	}
+	return nil
+}
+
+// exportedName turns a label such as "internal-admin" into the Go identifier
+// suffix "InternalAdmin"
+func exportedName(label string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range label {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if upper {
+				r = unicode.ToUpper(r)
+			}
+			sb.WriteRune(r)
+			upper = false
+		default:
+			upper = true
+		}
+	}
+	return sb.String()
+}
+
 // generateSchemaLiteral converts a map[string]interface{} to Go code literal
 func generateSchemaLiteral(m map[string]interface{}, indent int) string {
 	if len(m) == 0 {
@@ -358,6 +616,8 @@ func generateSchemaLiteral(m map[string]interface{}, indent int) string {
 // generateValueLiteral converts an interface{} value to Go code literal
 func generateValueLiteral(v interface{}, indent int) string {
 	switch val := v.(type) {
+	case nil:
+		return "nil"
 	case string:
 		return fmt.Sprintf("%q", val)
 	case int, int32, int64:
@@ -397,12 +657,31 @@ func generateGoogleSchemaLiteral(m map[string]interface{}, indent int) string {
 	sb.WriteString("&jsonschema.Schema{\n")
 	indentStr := strings.Repeat("\t", indent+1)
 
+	// Schema ($schema, set by the dialect parameter)
+	if uri, ok := m["$schema"].(string); ok {
+		sb.WriteString(indentStr)
+		fmt.Fprintf(&sb, "Schema: %q,\n", uri)
+	}
+
 	// Type
 	if typeVal, ok := m["type"].(string); ok {
 		sb.WriteString(indentStr)
 		fmt.Fprintf(&sb, "Type: %q,\n", typeVal)
 	}
 
+	// Types (e.g. [T, "null"] for nullable fields under a profile)
+	if types, ok := m["type"].([]interface{}); ok && len(types) > 0 {
+		sb.WriteString(indentStr)
+		sb.WriteString("Types: []string{")
+		for i, t := range types {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			fmt.Fprintf(&sb, "%q", t)
+		}
+		sb.WriteString("},\n")
+	}
+
 	// Title
 	if title, ok := m["title"].(string); ok {
 		sb.WriteString(indentStr)
@@ -421,6 +700,12 @@ func generateGoogleSchemaLiteral(m map[string]interface{}, indent int) string {
 		fmt.Fprintf(&sb, "Format: %q,\n", format)
 	}
 
+	// ContentEncoding (format: byte under the draft-07 and later dialects)
+	if encoding, ok := m["contentEncoding"].(string); ok {
+		sb.WriteString(indentStr)
+		fmt.Fprintf(&sb, "ContentEncoding: %q,\n", encoding)
+	}
+
 	// Pattern
 	if pattern, ok := m["pattern"].(string); ok {
 		sb.WriteString(indentStr)
@@ -453,6 +738,16 @@ func generateGoogleSchemaLiteral(m map[string]interface{}, indent int) string {
 		fmt.Fprintf(&sb, "Maximum: &[]float64{%v}[0],\n", max)
 	}
 
+	// ExclusiveMinimum / ExclusiveMaximum
+	if min, ok := m["exclusiveMinimum"].(float64); ok {
+		sb.WriteString(indentStr)
+		fmt.Fprintf(&sb, "ExclusiveMinimum: &[]float64{%v}[0],\n", min)
+	}
+	if max, ok := m["exclusiveMaximum"].(float64); ok {
+		sb.WriteString(indentStr)
+		fmt.Fprintf(&sb, "ExclusiveMaximum: &[]float64{%v}[0],\n", max)
+	}
+
 	// Enum
 	if enum, ok := m["enum"].([]interface{}); ok && len(enum) > 0 {
 		sb.WriteString(indentStr)
@@ -461,15 +756,23 @@ func generateGoogleSchemaLiteral(m map[string]interface{}, indent int) string {
 			if i > 0 {
 				sb.WriteString(", ")
 			}
-			fmt.Fprintf(&sb, "%q", e)
+			sb.WriteString(generateAnyLiteral(e))
 		}
 		sb.WriteString("},\n")
 	}
 
-	// Examples (our JSON emits a singular string "example"; v0.3.0 has only Examples []any)
+	// Examples: the singular string "example" field option and the message-level
+	// "examples" payloads both map onto Examples []any
+	var examples []interface{}
 	if example, ok := m["example"].(string); ok {
+		examples = append(examples, example)
+	}
+	if list, ok := m["examples"].([]interface{}); ok {
+		examples = append(examples, list...)
+	}
+	if len(examples) > 0 {
 		sb.WriteString(indentStr)
-		fmt.Fprintf(&sb, "Examples: []any{%q},\n", example)
+		fmt.Fprintf(&sb, "Examples: %s,\n", generateAnyLiteral(examples))
 	}
 
 	// Default is stored as json.RawMessage of the compact-encoded value
@@ -480,11 +783,53 @@ func generateGoogleSchemaLiteral(m map[string]interface{}, indent int) string {
 		}
 	}
 
-	// AdditionalProperties: only the explicit `false` case is representable;
-	// falseSchema() is unexported, so emit the equivalent &Schema{Not: &Schema{}}.
-	if ap, ok := m["additionalProperties"].(bool); ok && !ap {
+	// ReadOnly
+	if readOnly, ok := m["readOnly"].(bool); ok && readOnly {
+		sb.WriteString(indentStr)
+		sb.WriteString("ReadOnly: true,\n")
+	}
+
+	// Deprecated
+	if deprecated, ok := m["deprecated"].(bool); ok && deprecated {
+		sb.WriteString(indentStr)
+		sb.WriteString("Deprecated: true,\n")
+	}
+
+	// AdditionalProperties: falseSchema() is unexported, so emit the equivalent
+	// &Schema{Not: &Schema{}} for `false`, and the empty (true) schema for `true`.
+	if ap, ok := m["additionalProperties"].(bool); ok {
+		sb.WriteString(indentStr)
+		if ap {
+			sb.WriteString("AdditionalProperties: &jsonschema.Schema{},\n")
+		} else {
+			sb.WriteString("AdditionalProperties: &jsonschema.Schema{Not: &jsonschema.Schema{}},\n")
+		}
+	}
+
+	// MinProperties
+	if minProps, ok := m["minProperties"].(float64); ok {
+		sb.WriteString(indentStr)
+		fmt.Fprintf(&sb, "MinProperties: &[]int{%d}[0],\n", int(minProps))
+	}
+
+	// MaxProperties
+	if maxProps, ok := m["maxProperties"].(float64); ok {
 		sb.WriteString(indentStr)
-		sb.WriteString("AdditionalProperties: &jsonschema.Schema{Not: &jsonschema.Schema{}},\n")
+		fmt.Fprintf(&sb, "MaxProperties: &[]int{%d}[0],\n", int(maxProps))
+	}
+
+	// MinItems / MaxItems / UniqueItems
+	if minItems, ok := m["minItems"].(float64); ok {
+		sb.WriteString(indentStr)
+		fmt.Fprintf(&sb, "MinItems: &[]int{%d}[0],\n", int(minItems))
+	}
+	if maxItems, ok := m["maxItems"].(float64); ok {
+		sb.WriteString(indentStr)
+		fmt.Fprintf(&sb, "MaxItems: &[]int{%d}[0],\n", int(maxItems))
+	}
+	if unique, ok := m["uniqueItems"].(bool); ok && unique {
+		sb.WriteString(indentStr)
+		sb.WriteString("UniqueItems: true,\n")
 	}
 
 	// Properties (sorted so generated output is deterministic across runs)
@@ -521,6 +866,116 @@ func generateGoogleSchemaLiteral(m map[string]interface{}, indent int) string {
 		sb.WriteString("},\n")
 	}
 
+	// DependentRequired (sorted so generated output is deterministic across runs)
+	if deps, ok := m["dependentRequired"].(map[string]interface{}); ok && len(deps) > 0 {
+		sb.WriteString(indentStr)
+		sb.WriteString("DependentRequired: map[string][]string{\n")
+		keys := make([]string, 0, len(deps))
+		for key := range deps {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			requires, _ := deps[key].([]interface{})
+			names := make([]string, len(requires))
+			for i, r := range requires {
+				names[i] = fmt.Sprintf("%q", r)
+			}
+			sb.WriteString(strings.Repeat("\t", indent+2))
+			fmt.Fprintf(&sb, "%q: {%s},\n", key, strings.Join(names, ", "))
+		}
+		sb.WriteString(indentStr)
+		sb.WriteString("},\n")
+	}
+
+	// DependentSchemas (dependent_required under naming=both)
+	if deps, ok := m["dependentSchemas"].(map[string]interface{}); ok && len(deps) > 0 {
+		sb.WriteString(indentStr)
+		sb.WriteString("DependentSchemas: map[string]*jsonschema.Schema{\n")
+		keys := make([]string, 0, len(deps))
+		for key := range deps {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if dep, ok := deps[key].(map[string]interface{}); ok {
+				sb.WriteString(strings.Repeat("\t", indent+2))
+				fmt.Fprintf(&sb, "%q: %s,\n", key, generateGoogleSchemaLiteral(dep, indent+2))
+			}
+		}
+		sb.WriteString(indentStr)
+		sb.WriteString("},\n")
+	}
+
+	// Dependencies (dependentRequired and dependentSchemas before 2019-09)
+	if deps, ok := m["dependencies"].(map[string]interface{}); ok && len(deps) > 0 {
+		keys := make([]string, 0, len(deps))
+		for key := range deps {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var strs, schemas strings.Builder
+		for _, key := range keys {
+			switch dep := deps[key].(type) {
+			case []interface{}:
+				names := make([]string, len(dep))
+				for i, r := range dep {
+					names[i] = fmt.Sprintf("%q", r)
+				}
+				strs.WriteString(strings.Repeat("\t", indent+2))
+				fmt.Fprintf(&strs, "%q: {%s},\n", key, strings.Join(names, ", "))
+			case map[string]interface{}:
+				schemas.WriteString(strings.Repeat("\t", indent+2))
+				fmt.Fprintf(&schemas, "%q: %s,\n", key, generateGoogleSchemaLiteral(dep, indent+2))
+			}
+		}
+		if strs.Len() > 0 {
+			sb.WriteString(indentStr)
+			sb.WriteString("DependencyStrings: map[string][]string{\n")
+			sb.WriteString(strs.String())
+			sb.WriteString(indentStr)
+			sb.WriteString("},\n")
+		}
+		if schemas.Len() > 0 {
+			sb.WriteString(indentStr)
+			sb.WriteString("DependencySchemas: map[string]*jsonschema.Schema{\n")
+			sb.WriteString(schemas.String())
+			sb.WriteString(indentStr)
+			sb.WriteString("},\n")
+		}
+	}
+
+	// Const (e.g. the value tested by an if/then conditional)
+	if c, ok := m["const"]; ok {
+		sb.WriteString(indentStr)
+		fmt.Fprintf(&sb, "Const: &[]any{%s}[0],\n", generateAnyLiteral(c))
+	}
+
+	// If / Then / Else
+	for _, kw := range []struct{ key, field string }{{"if", "If"}, {"then", "Then"}, {"else", "Else"}} {
+		if sub, ok := m[kw.key].(map[string]interface{}); ok {
+			sb.WriteString(indentStr)
+			sb.WriteString(kw.field + ": ")
+			sb.WriteString(generateGoogleSchemaLiteral(sub, indent+1))
+			sb.WriteString(",\n")
+		}
+	}
+
+	// AllOf (e.g. several if/then conditionals)
+	if allOf, ok := m["allOf"].([]interface{}); ok && len(allOf) > 0 {
+		sb.WriteString(indentStr)
+		sb.WriteString("AllOf: []*jsonschema.Schema{\n")
+		for _, branch := range allOf {
+			if branchMap, ok := branch.(map[string]interface{}); ok {
+				sb.WriteString(strings.Repeat("\t", indent+2))
+				sb.WriteString(generateGoogleSchemaLiteral(branchMap, indent+2))
+				sb.WriteString(",\n")
+			}
+		}
+		sb.WriteString(indentStr)
+		sb.WriteString("},\n")
+	}
+
 	// Items (for arrays)
 	if items, ok := m["items"].(map[string]interface{}); ok {
 		sb.WriteString(indentStr)
@@ -544,12 +999,82 @@ func generateGoogleSchemaLiteral(m map[string]interface{}, indent int) string {
 		sb.WriteString("},\n")
 	}
 
+	// AnyOf (e.g. if/then/else under the draft-04 dialect)
+	if anyOf, ok := m["anyOf"].([]interface{}); ok && len(anyOf) > 0 {
+		sb.WriteString(indentStr)
+		sb.WriteString("AnyOf: []*jsonschema.Schema{\n")
+		for _, branch := range anyOf {
+			if branchMap, ok := branch.(map[string]interface{}); ok {
+				sb.WriteString(strings.Repeat("\t", indent+2))
+				sb.WriteString(generateGoogleSchemaLiteral(branchMap, indent+2))
+				sb.WriteString(",\n")
+			}
+		}
+		sb.WriteString(indentStr)
+		sb.WriteString("},\n")
+	}
+
+	// Not (e.g. a not_in validation rule)
+	if not, ok := m["not"].(map[string]interface{}); ok {
+		sb.WriteString(indentStr)
+		sb.WriteString("Not: ")
+		sb.WriteString(generateGoogleSchemaLiteral(not, indent+1))
+		sb.WriteString(",\n")
+	}
+
+	// Extra carries the x-* vendor extension keywords
+	extra := map[string]interface{}{}
+	for key, value := range m {
+		if strings.HasPrefix(key, "x-") {
+			extra[key] = value
+		}
+	}
+	if len(extra) > 0 {
+		sb.WriteString(indentStr)
+		fmt.Fprintf(&sb, "Extra: %s,\n", generateAnyLiteral(extra))
+	}
+
 	sb.WriteString(strings.Repeat("\t", indent))
 	sb.WriteString("}")
 
 	return sb.String()
 }
 
+// generateAnyLiteral converts a decoded JSON value to a single-line Go literal
+// of type any ([]any / map[string]any with sorted keys), matching the types
+// encoding/json produces when unmarshaling into interface{}
+func generateAnyLiteral(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return fmt.Sprintf("%q", val)
+	case float64:
+		return fmt.Sprintf("float64(%v)", val)
+	case bool:
+		return fmt.Sprintf("%t", val)
+	case []interface{}:
+		items := make([]string, len(val))
+		for i, item := range val {
+			items[i] = generateAnyLiteral(item)
+		}
+		return "[]any{" + strings.Join(items, ", ") + "}"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		entries := make([]string, len(keys))
+		for i, k := range keys {
+			entries[i] = fmt.Sprintf("%q: %s", k, generateAnyLiteral(val[k]))
+		}
+		return "map[string]any{" + strings.Join(entries, ", ") + "}"
+	default:
+		return fmt.Sprintf("%#v", val)
+	}
+}
+
 func toLowerCamelCase(s string) string {
 	if s == "" {
 		return ""
@@ -592,6 +1117,72 @@ func generateSchemaFile(plugin *protogen.Plugin, gen *jsonschema.Generator, file
 	return nil
 }
 
+func generateOpenAPIFile(plugin *protogen.Plugin, gen *jsonschema.Generator, file *protogen.File, params genParams) error {
+	// Create output filename: user.proto -> user.openapi.json
+	filename := strings.TrimSuffix(file.Desc.Path(), ".proto") + ".openapi.json"
+	g := plugin.NewGeneratedFile(filename, "")
+
+	title := string(file.Desc.Package())
+	if title == "" {
+		title = file.Desc.Path()
+	}
+	doc, err := gen.GenerateOpenAPI(jsonschema.OpenAPIVersion(params.openapi), title, file.Desc)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal OpenAPI document: %w", err)
+	}
+	g.P(string(data))
+
+	return nil
+}
+
+func generateMCPToolsFile(plugin *protogen.Plugin, gen *jsonschema.Generator, file *protogen.File) error {
+	if len(file.Services) == 0 {
+		return nil
+	}
+
+	// Create output filename: user.proto -> user.mcp_tools.json
+	filename := strings.TrimSuffix(file.Desc.Path(), ".proto") + ".mcp_tools.json"
+	g := plugin.NewGeneratedFile(filename, "")
+
+	tools, err := gen.GenerateMCPTools(file.Desc)
+	if err != nil {
+		return err
+	}
+
+	// Written as the result of a tools/list response
+	data, err := json.MarshalIndent(map[string]interface{}{"tools": tools}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal MCP tools: %w", err)
+	}
+	g.P(string(data))
+
+	return nil
+}
+
+func generateToolsFile(plugin *protogen.Plugin, gen *jsonschema.Generator, file *protogen.File, params genParams) error {
+	tools, err := gen.GenerateTools(jsonschema.ToolProvider(params.provider), file.Desc)
+	if err != nil || tools == nil {
+		return err
+	}
+
+	// Create output filename: user.proto -> user.tools.json
+	filename := strings.TrimSuffix(file.Desc.Path(), ".proto") + ".tools.json"
+	g := plugin.NewGeneratedFile(filename, "")
+
+	data, err := json.MarshalIndent(tools, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal tools: %w", err)
+	}
+	g.P(string(data))
+
+	return nil
+}
+
 func shouldGenerateSchema(message *protogen.Message) bool {
 	opts, _ := message.Desc.Options().(*descriptorpb.MessageOptions)
 	return jsonschema.ShouldGenerateSchema(opts)
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckAudienceSuffixes(t *testing.T) {
+	tests := []struct {
+		audiences []string
+		localized bool
+		wantErr   string
+	}{
+		{audiences: []string{"admin", "internal-admin"}},
+		{audiences: []string{"by-locale"}},
+		{audiences: []string{"admin", "Admin"}, wantErr: `audiences "admin" and "Admin"`},
+		{audiences: []string{"_"}, wantErr: `audience "_" has no letters or digits`},
+		{audiences: []string{"by-locale"}, localized: true, wantErr: "clashes with the locale map"},
+	}
+
+	for _, tt := range tests {
+		err := checkAudienceSuffixes(tt.audiences, tt.localized)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("checkAudienceSuffixes(%q) = %v", tt.audiences, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("checkAudienceSuffixes(%q) = %v, want error containing %q", tt.audiences, err, tt.wantErr)
+		}
+	}
+}
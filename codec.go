@@ -0,0 +1,192 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+// Codec converts messages to and from the JSON described by their generated
+// schemas. It wraps protojson, renaming properties that have a json_name
+// option and dropping hidden fields, at every level of nesting.
+type Codec struct {
+	MarshalOptions   protojson.MarshalOptions
+	UnmarshalOptions protojson.UnmarshalOptions
+}
+
+// Marshal encodes m with the default Codec
+func Marshal(m proto.Message) ([]byte, error) {
+	return Codec{}.Marshal(m)
+}
+
+// Unmarshal decodes data into m with the default Codec
+func Unmarshal(data []byte, m proto.Message) error {
+	return Codec{}.Unmarshal(data, m)
+}
+
+// Marshal encodes m as protojson does, then renames json_name fields to their
+// schema names and removes hidden fields
+func (c Codec) Marshal(m proto.Message) ([]byte, error) {
+	data, err := c.MarshalOptions.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	value, err := decodeJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	renameFields(m.ProtoReflect().Descriptor(), value, toSchemaName)
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if c.MarshalOptions.Multiline || c.MarshalOptions.Indent != "" {
+		indent := c.MarshalOptions.Indent
+		if indent == "" {
+			indent = "  "
+		}
+		enc.SetIndent("", indent)
+	}
+	if err := enc.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// Unmarshal decodes data that follows the generated schema into m: schema
+// names of json_name fields are mapped back to their protojson names and
+// hidden fields are ignored before protojson decodes the result
+func (c Codec) Unmarshal(data []byte, m proto.Message) error {
+	value, err := decodeJSON(data)
+	if err != nil {
+		return err
+	}
+	renameFields(m.ProtoReflect().Descriptor(), value, toProtoJSONName)
+
+	data, err = json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return c.UnmarshalOptions.Unmarshal(data, m)
+}
+
+// decodeJSON decodes data keeping numbers as json.Number, so that values
+// such as 64-bit integers pass through unchanged
+func decodeJSON(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return value, nil
+}
+
+// fieldRenamer maps the key of field in a JSON object to its new key; ok is
+// false when the key does not name field
+type fieldRenamer func(field protoreflect.FieldDescriptor, key string) (renamed string, ok bool)
+
+// toSchemaName renames a protojson key to the json_name option of its field
+func toSchemaName(field protoreflect.FieldDescriptor, key string) (string, bool) {
+	if key != field.JSONName() && key != string(field.Name()) {
+		return "", false
+	}
+	if name, ok := jsonNameOption(field); ok {
+		return name, true
+	}
+	return key, true
+}
+
+// toProtoJSONName renames the json_name option key of a field, or any name
+// protojson accepts for it, to a key protojson decodes
+func toProtoJSONName(field protoreflect.FieldDescriptor, key string) (string, bool) {
+	if name, ok := jsonNameOption(field); ok && key == name {
+		return field.JSONName(), true
+	}
+	if key == field.JSONName() || key == string(field.Name()) {
+		return key, true
+	}
+	return "", false
+}
+
+// jsonNameOption returns the json_name option of field, if set
+func jsonNameOption(field protoreflect.FieldDescriptor) (string, bool) {
+	opts, _ := field.Options().(*descriptorpb.FieldOptions)
+	if opts == nil || !proto.HasExtension(opts, jsonschemapb.E_JsonName) {
+		return "", false
+	}
+	return proto.GetExtension(opts, jsonschemapb.E_JsonName).(string), true
+}
+
+// renameFields rewrites the keys of the JSON object value, which encodes a
+// message of type md, with rename, drops hidden fields and recurses into
+// nested messages. Keys that name no field are left alone. Well-known types
+// have their own JSON forms and are not rewritten.
+func renameFields(md protoreflect.MessageDescriptor, value interface{}, rename fieldRenamer) {
+	obj, ok := value.(map[string]interface{})
+	if !ok || isWellKnownType(md) {
+		return
+	}
+
+	renamed := make(map[string]interface{}, len(obj))
+	for key, fieldValue := range obj {
+		field, newKey := lookupField(md, key, rename)
+		if field == nil {
+			renamed[key] = fieldValue
+			continue
+		}
+		if opts, _ := field.Options().(*descriptorpb.FieldOptions); opts != nil &&
+			proto.HasExtension(opts, jsonschemapb.E_Hidden) && proto.GetExtension(opts, jsonschemapb.E_Hidden).(bool) {
+			continue
+		}
+		renameNested(field, fieldValue, rename)
+		renamed[newKey] = fieldValue
+	}
+
+	for key := range obj {
+		delete(obj, key)
+	}
+	for key, fieldValue := range renamed {
+		obj[key] = fieldValue
+	}
+}
+
+// lookupField finds the field of md that key names under rename
+func lookupField(md protoreflect.MessageDescriptor, key string, rename fieldRenamer) (protoreflect.FieldDescriptor, string) {
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		if newKey, ok := rename(fields.Get(i), key); ok {
+			return fields.Get(i), newKey
+		}
+	}
+	return nil, ""
+}
+
+// renameNested applies renameFields to the messages held by a field value
+func renameNested(field protoreflect.FieldDescriptor, value interface{}, rename fieldRenamer) {
+	switch {
+	case field.IsMap():
+		if field.MapValue().Message() == nil {
+			return
+		}
+		entries, _ := value.(map[string]interface{})
+		for _, entry := range entries {
+			renameFields(field.MapValue().Message(), entry, rename)
+		}
+	case field.Message() == nil:
+	case field.IsList():
+		items, _ := value.([]interface{})
+		for _, item := range items {
+			renameFields(field.Message(), item, rename)
+		}
+	default:
+		renameFields(field.Message(), value, rename)
+	}
+}
@@ -0,0 +1,140 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+// codecMessage builds a User message with a renamed field, a hidden field and
+// renamed fields inside singular and repeated nested messages
+func codecMessage(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+	fd := testFile(t,
+		testMessage("Address", nil,
+			testField("zip_code", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(ext(jsonschemapb.E_JsonName, "postalCode"))),
+		),
+		testMessage("User", nil,
+			testField("email", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(ext(jsonschemapb.E_JsonName, "emailAddress"))),
+			testField("secret", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(ext(jsonschemapb.E_Hidden, true))),
+			testField("user_id", 3, descriptorpb.FieldDescriptorProto_TYPE_INT64, nil),
+			testMessageField("home", 4, ".test.Address", nil),
+			repeated(testMessageField("others", 5, ".test.Address", nil)),
+			testMessageField("created_at", 6, ".google.protobuf.Timestamp", nil),
+		),
+	)
+	return fd.Messages().ByName("User")
+}
+
+const codecPayload = `{
+	"emailAddress": "ada@example.com",
+	"userId": "9007199254740993",
+	"home": {"postalCode": "10115"},
+	"others": [{"postalCode": "75001"}],
+	"createdAt": "2024-01-02T03:04:05Z"
+}`
+
+func TestCodec_Unmarshal(t *testing.T) {
+	md := codecMessage(t)
+	msg := dynamicpb.NewMessage(md)
+	if err := Unmarshal([]byte(codecPayload), msg); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if got := msg.Get(md.Fields().ByName("email")).String(); got != "ada@example.com" {
+		t.Errorf("email = %q", got)
+	}
+	if got := msg.Get(md.Fields().ByName("user_id")).Int(); got != 9007199254740993 {
+		t.Errorf("user_id = %d", got)
+	}
+	home := msg.Get(md.Fields().ByName("home")).Message()
+	if got := home.Get(home.Descriptor().Fields().ByName("zip_code")).String(); got != "10115" {
+		t.Errorf("home.zip_code = %q", got)
+	}
+	others := msg.Get(md.Fields().ByName("others")).List()
+	if others.Len() != 1 {
+		t.Fatalf("expected 1 other address, got %d", others.Len())
+	}
+	other := others.Get(0).Message()
+	if got := other.Get(other.Descriptor().Fields().ByName("zip_code")).String(); got != "75001" {
+		t.Errorf("others[0].zip_code = %q", got)
+	}
+}
+
+func TestCodec_UnmarshalIgnoresHiddenFields(t *testing.T) {
+	md := codecMessage(t)
+	msg := dynamicpb.NewMessage(md)
+	if err := Unmarshal([]byte(`{"secret": "s3cr3t", "email": "ada@example.com"}`), msg); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if msg.Has(md.Fields().ByName("secret")) {
+		t.Error("expected hidden field to be ignored")
+	}
+	if got := msg.Get(md.Fields().ByName("email")).String(); got != "ada@example.com" {
+		t.Errorf("expected protojson name to still be accepted, got email = %q", got)
+	}
+}
+
+func TestCodec_MarshalRoundTrip(t *testing.T) {
+	md := codecMessage(t)
+	msg := dynamicpb.NewMessage(md)
+	if err := Unmarshal([]byte(codecPayload), msg); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	msg.Set(md.Fields().ByName("secret"), protoreflect.ValueOfString("s3cr3t"))
+
+	data, err := Marshal(msg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var got, want map[string]interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("invalid output %s: %v", data, err)
+	}
+	if err := json.Unmarshal([]byte(codecPayload), &want); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Marshal = %s, want %s", data, codecPayload)
+	}
+
+	decoded := dynamicpb.NewMessage(md)
+	if err := Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Unmarshal of marshaled data failed: %v", err)
+	}
+	msg.Clear(md.Fields().ByName("secret"))
+	if !proto.Equal(msg, decoded) {
+		t.Errorf("round trip mismatch: %v != %v", msg, decoded)
+	}
+}
+
+func TestCodec_ProtoNames(t *testing.T) {
+	md := codecMessage(t)
+	msg := dynamicpb.NewMessage(md)
+	if err := Unmarshal([]byte(codecPayload), msg); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	codec := Codec{MarshalOptions: protojson.MarshalOptions{UseProtoNames: true}}
+	data, err := codec.Marshal(msg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("invalid output %s: %v", data, err)
+	}
+	for _, key := range []string{"emailAddress", "user_id", "created_at"} {
+		if _, ok := got[key]; !ok {
+			t.Errorf("expected key %q in %s", key, data)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package jsonschema
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+// applyConditionals renders the dependent_required and conditional message
+// options as dependentRequired and if/then/else keywords. A single conditional
+// rule is written inline; several rules are combined under allOf. Under
+// NamingBoth each constraint holds for every name a field is accepted under:
+// a field that requires one with two names is written to dependentSchemas,
+// since dependentRequired cannot express the alternative. Fields outside the
+// generator's audience are left out of the constraints, and rules triggered by
+// such a field are skipped.
+func (g *Generator) applyConditionals(schema Schema, md protoreflect.MessageDescriptor, msgOpts *descriptorpb.MessageOptions) error {
+	if proto.HasExtension(msgOpts, jsonschemapb.E_DependentRequired) {
+		var triggers []string
+		triggerNames := map[string][]string{}
+		triggerRequires := map[string][][]string{}
+		for _, dep := range proto.GetExtension(msgOpts, jsonschemapb.E_DependentRequired).([]*jsonschemapb.DependentRequired) {
+			names, _, err := g.referencedField(md, "dependent_required", dep.GetField())
+			if err != nil {
+				return err
+			}
+			requires, err := g.referencedFieldNames(md, "dependent_required", dep.GetRequires())
+			if err != nil {
+				return err
+			}
+			if names == nil || len(requires) == 0 {
+				continue
+			}
+			if _, ok := triggerNames[dep.GetField()]; !ok {
+				triggers = append(triggers, dep.GetField())
+				triggerNames[dep.GetField()] = names
+			}
+			triggerRequires[dep.GetField()] = append(triggerRequires[dep.GetField()], requires...)
+		}
+
+		dependentRequired := map[string][]string{}
+		dependentSchemas := map[string]interface{}{}
+		for _, trigger := range triggers {
+			requires := triggerRequires[trigger]
+			flat, ok := singleNames(requires)
+			for _, name := range triggerNames[trigger] {
+				if ok {
+					dependentRequired[name] = flat
+				} else {
+					dependentSchemas[name] = requiredSchema(requires)
+				}
+			}
+		}
+		if len(dependentRequired) > 0 {
+			schema["dependentRequired"] = dependentRequired
+		}
+		if len(dependentSchemas) > 0 {
+			schema["dependentSchemas"] = dependentSchemas
+		}
+	}
+
+	if proto.HasExtension(msgOpts, jsonschemapb.E_Conditional) {
+		var conditions []interface{}
+		for _, rule := range proto.GetExtension(msgOpts, jsonschemapb.E_Conditional).([]*jsonschemapb.ConditionalRule) {
+			condition, err := g.conditionSchema(md, rule)
+			if err != nil {
+				return err
+			}
+			if condition != nil {
+				conditions = append(conditions, condition)
+			}
+		}
+		if len(conditions) == 1 {
+			for k, v := range conditions[0].(Schema) {
+				schema[k] = v
+			}
+		} else if len(conditions) > 1 {
+			schema["allOf"] = conditions
+		}
+	}
+
+	return nil
+}
+
+// conditionSchema builds the if/then(/else) schema of a single conditional
+// rule, or returns nil when the field it tests, or every field it requires, is
+// outside the audience
+func (g *Generator) conditionSchema(md protoreflect.MessageDescriptor, rule *jsonschemapb.ConditionalRule) (Schema, error) {
+	names, field, err := g.referencedField(md, "conditional", rule.GetField())
+	if err != nil {
+		return nil, err
+	}
+	value, err := conditionValue(field, rule.GetEquals())
+	if err != nil {
+		return nil, fmt.Errorf("%s: conditional on %q: %w", md.FullName(), rule.GetField(), err)
+	}
+	thenRequired, err := g.referencedFieldNames(md, "conditional", rule.GetThenRequired())
+	if err != nil {
+		return nil, err
+	}
+	elseRequired, err := g.referencedFieldNames(md, "conditional", rule.GetElseRequired())
+	if err != nil {
+		return nil, err
+	}
+	if names == nil || len(thenRequired)+len(elseRequired) == 0 {
+		return nil, nil
+	}
+
+	properties := make(map[string]interface{}, len(names))
+	for _, name := range names {
+		properties[name] = Schema{"const": value}
+	}
+	ifSchema := requiredSchema([][]string{names})
+	ifSchema["properties"] = properties
+
+	condition := Schema{"if": ifSchema}
+	if len(thenRequired) > 0 {
+		condition["then"] = requiredSchema(thenRequired)
+	}
+	if len(elseRequired) > 0 {
+		condition["else"] = requiredSchema(elseRequired)
+	}
+	return condition, nil
+}
+
+// requiredSchema requires each of fields under any one of its names. Fields
+// with a single name are listed in required; the others become anyOf
+// alternatives, combined under allOf when there are several.
+func requiredSchema(fields [][]string) Schema {
+	schema := Schema{}
+	var required []string
+	var alternatives []interface{}
+	for _, names := range fields {
+		if len(names) == 1 {
+			required = append(required, names[0])
+			continue
+		}
+		anyOf := make([]interface{}, len(names))
+		for i, name := range names {
+			anyOf[i] = Schema{"required": []string{name}}
+		}
+		alternatives = append(alternatives, Schema{"anyOf": anyOf})
+	}
+
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	switch len(alternatives) {
+	case 0:
+	case 1:
+		schema["anyOf"] = alternatives[0].(Schema)["anyOf"]
+	default:
+		schema["allOf"] = alternatives
+	}
+	return schema
+}
+
+// singleNames flattens fields into their names when each field has only one
+func singleNames(fields [][]string) ([]string, bool) {
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if len(field) != 1 {
+			return nil, false
+		}
+		names = append(names, field[0])
+	}
+	return names, true
+}
+
+// conditionValue interprets a conditional rule's equals value for field:
+// string and enum values are taken literally, bool and numeric values are
+// parsed as literals of the matching type.
+func conditionValue(field protoreflect.FieldDescriptor, equals string) (interface{}, error) {
+	if field.Cardinality() == protoreflect.Repeated {
+		return nil, fmt.Errorf("repeated fields cannot be compared")
+	}
+
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return equals, nil
+	case protoreflect.EnumKind:
+		if field.Enum().Values().ByName(protoreflect.Name(equals)) == nil {
+			return nil, fmt.Errorf("%q is not a value of enum %s", equals, field.Enum().FullName())
+		}
+		return equals, nil
+	case protoreflect.BoolKind:
+		value, err := strconv.ParseBool(equals)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a boolean", equals)
+		}
+		return value, nil
+	case protoreflect.MessageKind, protoreflect.GroupKind, protoreflect.BytesKind:
+		return nil, fmt.Errorf("%s fields cannot be compared", field.Kind())
+	default:
+		value, err := strconv.ParseFloat(equals, 64)
+		if err != nil || math.IsInf(value, 0) || math.IsNaN(value) {
+			return nil, fmt.Errorf("%q is not a number", equals)
+		}
+		return value, nil
+	}
+}
+
+// referencedField resolves a proto field name used by a message-level option
+// to its JSON property names, primary name first, rejecting unknown and
+// hidden fields. Fields outside the generator's audience resolve to no names.
+func (g *Generator) referencedField(md protoreflect.MessageDescriptor, option, fieldName string) ([]string, protoreflect.FieldDescriptor, error) {
+	field := md.Fields().ByName(protoreflect.Name(fieldName))
+	if field == nil {
+		return nil, nil, fmt.Errorf("%s: %s references unknown field %q", md.FullName(), option, fieldName)
+	}
+	fieldOpts := field.Options().(*descriptorpb.FieldOptions)
+	if g.isFieldHidden(fieldOpts) {
+		return nil, nil, fmt.Errorf("%s: %s references hidden field %q", md.FullName(), option, fieldName)
+	}
+	if !g.isFieldVisible(field) {
+		return nil, field, nil
+	}
+	return g.fieldNames(field, fieldOpts), field, nil
+}
+
+// referencedFieldNames resolves a list of proto field names to the JSON
+// property names of each field, leaving out fields outside the audience
+func (g *Generator) referencedFieldNames(md protoreflect.MessageDescriptor, option string, fieldNames []string) ([][]string, error) {
+	names := make([][]string, 0, len(fieldNames))
+	for _, fieldName := range fieldNames {
+		resolved, _, err := g.referencedField(md, option, fieldName)
+		if err != nil {
+			return nil, err
+		}
+		if resolved != nil {
+			names = append(names, resolved)
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,198 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+// shipRequestMessage describes a shipping request whose method enum drives which
+// contact fields are required.
+func shipRequestMessage(t *testing.T, opts *descriptorpb.MessageOptions) *descriptorpb.DescriptorProto {
+	t.Helper()
+	method := testField("method", 1, descriptorpb.FieldDescriptorProto_TYPE_ENUM, nil)
+	method.TypeName = proto.String(".test.Method")
+	return testMessage("ShipRequest", opts,
+		method,
+		testField("phone", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+		testField("start_date", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+		testField("end_date", 4, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+		testField("gift", 5, descriptorpb.FieldDescriptorProto_TYPE_BOOL, nil),
+		testField("note", 6, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+		testField("internal", 7, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(ext(jsonschemapb.E_Hidden, true))),
+	)
+}
+
+// generateShipping builds ShipRequest with the given options and generates its schema
+func generateShipping(t *testing.T, opts *descriptorpb.MessageOptions) (Schema, error) {
+	t.Helper()
+	return generateShippingWith(t, NewGenerator(), opts)
+}
+
+// generateShippingWith is generateShipping with a configured generator
+func generateShippingWith(t *testing.T, g *Generator, opts *descriptorpb.MessageOptions) (Schema, error) {
+	t.Helper()
+	fd := testFileWithEnums(t, []*descriptorpb.EnumDescriptorProto{{
+		Name: proto.String("Method"),
+		Value: []*descriptorpb.EnumValueDescriptorProto{
+			{Name: proto.String("STANDARD"), Number: proto.Int32(0)},
+			{Name: proto.String("EXPRESS"), Number: proto.Int32(1)},
+		},
+	}}, shipRequestMessage(t, opts))
+	return g.GenerateSchema(fd.Messages().ByName("ShipRequest"))
+}
+
+func TestConditional_DependentRequired(t *testing.T) {
+	schema, err := generateShipping(t, msgOpts(ext(jsonschemapb.E_DependentRequired, []*jsonschemapb.DependentRequired{
+		{Field: "end_date", Requires: []string{"start_date"}},
+	})))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	m := mustSchemaMap(t, schema)
+	deps := m["dependentRequired"].(map[string]interface{})
+	if got := deps["endDate"].([]interface{}); len(got) != 1 || got[0] != "startDate" {
+		t.Errorf("expected endDate -> [startDate], got %v", deps)
+	}
+}
+
+func TestConditional_DependentRequiredNamingBoth(t *testing.T) {
+	g := NewGenerator()
+	g.SetNamingStrategy(NamingBoth)
+	schema, err := generateShippingWith(t, g, msgOpts(ext(jsonschemapb.E_DependentRequired, []*jsonschemapb.DependentRequired{
+		{Field: "end_date", Requires: []string{"start_date", "note"}},
+		{Field: "note", Requires: []string{"phone"}},
+	})))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"dependentRequired": schema["dependentRequired"],
+		"dependentSchemas":  schema["dependentSchemas"],
+	})
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	endDate := `{"anyOf":[{"required":["startDate"]},{"required":["start_date"]}],"required":["note"]}`
+	want := `{"dependentRequired":{"note":["phone"]},"dependentSchemas":{"endDate":` + endDate + `,"end_date":` + endDate + `}}`
+	if string(data) != want {
+		t.Errorf("unexpected dependencies\n got: %s\nwant: %s", data, want)
+	}
+}
+
+func TestConditional_NamingBoth(t *testing.T) {
+	g := NewGenerator()
+	g.SetNamingStrategy(NamingBoth)
+	schema, err := generateShippingWith(t, g, msgOpts(ext(jsonschemapb.E_Conditional, []*jsonschemapb.ConditionalRule{
+		{Field: "start_date", Equals: "today", ThenRequired: []string{"end_date", "phone"}},
+	})))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	data, err := json.Marshal(map[string]interface{}{"if": schema["if"], "then": schema["then"]})
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	want := `{"if":{"anyOf":[{"required":["startDate"]},{"required":["start_date"]}],"properties":{"startDate":{"const":"today"},"start_date":{"const":"today"}}},` +
+		`"then":{"anyOf":[{"required":["endDate"]},{"required":["end_date"]}],"required":["phone"]}}`
+	if string(data) != want {
+		t.Errorf("unexpected conditional\n got: %s\nwant: %s", data, want)
+	}
+}
+
+func TestConditional_SingleRuleInline(t *testing.T) {
+	schema, err := generateShipping(t, msgOpts(ext(jsonschemapb.E_Conditional, []*jsonschemapb.ConditionalRule{
+		{Field: "method", Equals: "EXPRESS", ThenRequired: []string{"phone"}, ElseRequired: []string{"note"}},
+	})))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	data, err := json.Marshal(map[string]interface{}{"if": schema["if"], "then": schema["then"], "else": schema["else"]})
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	want := `{"else":{"required":["note"]},"if":{"properties":{"method":{"const":"EXPRESS"}},"required":["method"]},"then":{"required":["phone"]}}`
+	if string(data) != want {
+		t.Errorf("unexpected conditional\n got: %s\nwant: %s", data, want)
+	}
+	if _, ok := schema["allOf"]; ok {
+		t.Error("a single rule must not be wrapped in allOf")
+	}
+}
+
+func TestConditional_MultipleRulesAllOf(t *testing.T) {
+	schema, err := generateShipping(t, msgOpts(ext(jsonschemapb.E_Conditional, []*jsonschemapb.ConditionalRule{
+		{Field: "method", Equals: "EXPRESS", ThenRequired: []string{"phone"}},
+		{Field: "gift", Equals: "true", ThenRequired: []string{"note"}},
+	})))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	m := mustSchemaMap(t, schema)
+	allOf, ok := m["allOf"].([]interface{})
+	if !ok || len(allOf) != 2 {
+		t.Fatalf("expected two allOf conditions, got %v", m["allOf"])
+	}
+	giftIf := allOf[1].(map[string]interface{})["if"].(map[string]interface{})
+	gift := giftIf["properties"].(map[string]interface{})["gift"].(map[string]interface{})
+	if gift["const"] != true {
+		t.Errorf("expected boolean const true, got %v", gift["const"])
+	}
+}
+
+func TestConditional_Errors(t *testing.T) {
+	cases := []struct {
+		name string
+		opts *descriptorpb.MessageOptions
+		want string
+	}{
+		{"unknown field", msgOpts(ext(jsonschemapb.E_DependentRequired, []*jsonschemapb.DependentRequired{
+			{Field: "end_date", Requires: []string{"begin"}},
+		})), `unknown field "begin"`},
+		{"hidden field", msgOpts(ext(jsonschemapb.E_Conditional, []*jsonschemapb.ConditionalRule{
+			{Field: "method", Equals: "EXPRESS", ThenRequired: []string{"internal"}},
+		})), `hidden field "internal"`},
+		{"bad enum value", msgOpts(ext(jsonschemapb.E_Conditional, []*jsonschemapb.ConditionalRule{
+			{Field: "method", Equals: "OVERNIGHT", ThenRequired: []string{"phone"}},
+		})), "not a value of enum"},
+		{"bad bool", msgOpts(ext(jsonschemapb.E_Conditional, []*jsonschemapb.ConditionalRule{
+			{Field: "gift", Equals: "yes", ThenRequired: []string{"note"}},
+		})), "not a boolean"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := generateShipping(t, tc.opts)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestConditional_OrderedSchemaKeywords(t *testing.T) {
+	fd := testFile(t,
+		testMessage("Range", msgOpts(ext(jsonschemapb.E_DependentRequired, []*jsonschemapb.DependentRequired{
+			{Field: "end_date", Requires: []string{"start_date"}},
+		})),
+			testField("start_date", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+			testField("end_date", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+		),
+	)
+	ordered, err := NewGeneratorWithOptions(true).GenerateOrderedSchema(fd.Messages().ByName("Range"))
+	if err != nil {
+		t.Fatalf("GenerateOrderedSchema failed: %v", err)
+	}
+	data, err := json.Marshal(ordered)
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+	want := `{"type":"object","title":"Range","properties":{"startDate":{"type":"string"},"endDate":{"type":"string"}},"dependentRequired":{"endDate":["startDate"]}}`
+	if string(data) != want {
+		t.Errorf("unexpected ordered schema\n got: %s\nwant: %s", data, want)
+	}
+}
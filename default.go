@@ -0,0 +1,173 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+// fieldDefault parses the default option of field and checks that it is a
+// value of the field's type: scalars must have the JSON type the schema
+// advertises, and the value must decode with protojson (ranges, enum value
+// names, base64, timestamps, ...). Fields with a registered type mapper only
+// need valid JSON, since their JSON form is up to the mapper.
+func (g *Generator) fieldDefault(field protoreflect.FieldDescriptor, opts *descriptorpb.FieldOptions) (interface{}, bool, error) {
+	if !proto.HasExtension(opts, jsonschemapb.E_Default) {
+		return nil, false, nil
+	}
+	text := proto.GetExtension(opts, jsonschemapb.E_Default).(string)
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		return nil, false, fmt.Errorf("%s: invalid default %q: %w", field.FullName(), text, err)
+	}
+	if _, mapped := g.typeMapper(field); mapped {
+		return value, true, nil
+	}
+	if err := checkDefaultType(field, value); err != nil {
+		return nil, false, fmt.Errorf("%s: invalid default %q: %w", field.FullName(), text, err)
+	}
+	// well-known types have their own JSON forms, so their fields cannot be
+	// decoded on their own
+	if isWellKnownType(field.ContainingMessage()) {
+		return value, true, nil
+	}
+	if _, err := decodeDefault(field, dynamicpb.NewMessage(field.ContainingMessage()), text); err != nil {
+		return nil, false, fmt.Errorf("%s: invalid default %q: %w", field.FullName(), text, err)
+	}
+	return value, true, nil
+}
+
+// checkDefaultType checks the JSON type of a default value against the kind
+// of field, looking into the elements of repeated and map fields. protojson
+// alone is more lenient: it accepts quoted numbers and enum numbers, which
+// the generated schema does not.
+func checkDefaultType(field protoreflect.FieldDescriptor, value interface{}) error {
+	switch {
+	case field.IsMap():
+		entries, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("expected an object")
+		}
+		for _, entry := range entries {
+			if err := checkScalarType(field.MapValue(), entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	case field.IsList():
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("expected an array")
+		}
+		for _, item := range items {
+			if err := checkScalarType(field, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return checkScalarType(field, value)
+}
+
+// checkScalarType checks the JSON type of a single value of field's kind;
+// message values are left to protojson
+func checkScalarType(field protoreflect.FieldDescriptor, value interface{}) error {
+	var ok bool
+	var want string
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		_, ok = value.(bool)
+		want = "a boolean"
+	case protoreflect.StringKind, protoreflect.BytesKind, protoreflect.EnumKind:
+		_, ok = value.(string)
+		want = "a string"
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		_, ok = value.(float64)
+		want = "a number"
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return nil
+	default:
+		n, isNumber := value.(float64)
+		ok = isNumber && n == float64(int64(n))
+		want = "an integer"
+	}
+	if !ok {
+		return fmt.Errorf("expected %s for %s field", want, field.Kind())
+	}
+	return nil
+}
+
+// decodeDefault decodes the JSON default text of field into the field of a
+// fresh message like m and returns the decoded value
+func decodeDefault(field protoreflect.FieldDescriptor, m protoreflect.Message, text string) (protoreflect.Value, error) {
+	key, err := json.Marshal(field.JSONName())
+	if err != nil {
+		return protoreflect.Value{}, err
+	}
+	tmp := m.New()
+	payload := `{` + string(key) + `:` + text + `}`
+	if err := (protojson.UnmarshalOptions{AllowPartial: true}).Unmarshal([]byte(payload), tmp.Interface()); err != nil {
+		return protoreflect.Value{}, err
+	}
+	return tmp.Get(field), nil
+}
+
+// ApplyDefaults sets every unset field of m that has a default option to that
+// default, descending into the nested messages m holds. For fields without
+// presence, such as proto3 scalars, the zero value counts as unset. A oneof
+// member is only defaulted while no member of its oneof is set, so a value
+// already chosen is never replaced. This keeps server-side behavior in line
+// with the defaults the schema advertises.
+func ApplyDefaults(m proto.Message) error {
+	return applyDefaults(m.ProtoReflect())
+}
+
+func applyDefaults(m protoreflect.Message) error {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if oneof := field.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() && m.WhichOneof(oneof) != nil {
+			continue
+		}
+		opts, _ := field.Options().(*descriptorpb.FieldOptions)
+		if !m.Has(field) && opts != nil && proto.HasExtension(opts, jsonschemapb.E_Default) {
+			text := proto.GetExtension(opts, jsonschemapb.E_Default).(string)
+			value, err := decodeDefault(field, m, text)
+			if err != nil {
+				return fmt.Errorf("%s: invalid default %q: %w", field.FullName(), text, err)
+			}
+			m.Set(field, value)
+		}
+	}
+
+	var err error
+	m.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case field.IsMap():
+			if field.MapValue().Message() != nil {
+				value.Map().Range(func(_ protoreflect.MapKey, entry protoreflect.Value) bool {
+					err = applyDefaults(entry.Message())
+					return err == nil
+				})
+			}
+		case field.Message() == nil:
+		case field.IsList():
+			list := value.List()
+			for i := 0; i < list.Len() && err == nil; i++ {
+				err = applyDefaults(list.Get(i).Message())
+			}
+		default:
+			err = applyDefaults(value.Message())
+		}
+		return err == nil
+	})
+	return err
+}
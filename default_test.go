@@ -0,0 +1,183 @@
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+func defaultField(name string, typ descriptorpb.FieldDescriptorProto_Type, def string) *descriptorpb.FieldDescriptorProto {
+	return testField(name, 1, typ, fieldOpts(ext(jsonschemapb.E_Default, def)))
+}
+
+func TestFieldDefault_TypeChecked(t *testing.T) {
+	tests := []struct {
+		name  string
+		field *descriptorpb.FieldDescriptorProto
+		want  interface{}
+		err   string
+	}{
+		{"int32", defaultField("count", descriptorpb.FieldDescriptorProto_TYPE_INT32, "3"), float64(3), ""},
+		{"int32 string", defaultField("count", descriptorpb.FieldDescriptorProto_TYPE_INT32, `"abc"`), nil, "expected an integer"},
+		{"int32 quoted number", defaultField("count", descriptorpb.FieldDescriptorProto_TYPE_INT32, `"3"`), nil, "expected an integer"},
+		{"int32 fraction", defaultField("count", descriptorpb.FieldDescriptorProto_TYPE_INT32, "1.5"), nil, "expected an integer"},
+		{"int32 overflow", defaultField("count", descriptorpb.FieldDescriptorProto_TYPE_INT32, "4294967296"), nil, "invalid value for int32"},
+		{"uint32 negative", defaultField("count", descriptorpb.FieldDescriptorProto_TYPE_UINT32, "-1"), nil, "invalid value for uint32"},
+		{"double", defaultField("ratio", descriptorpb.FieldDescriptorProto_TYPE_DOUBLE, "0.5"), 0.5, ""},
+		{"bool", defaultField("on", descriptorpb.FieldDescriptorProto_TYPE_BOOL, "1"), nil, "expected a boolean"},
+		{"string", defaultField("name", descriptorpb.FieldDescriptorProto_TYPE_STRING, `"ada"`), "ada", ""},
+		{"bytes", defaultField("data", descriptorpb.FieldDescriptorProto_TYPE_BYTES, `"not base64!"`), nil, "invalid value for bytes"},
+		{"repeated", repeated(defaultField("tags", descriptorpb.FieldDescriptorProto_TYPE_STRING, `["a", 1]`)), nil, "expected a string"},
+		{"repeated scalar", repeated(defaultField("tags", descriptorpb.FieldDescriptorProto_TYPE_STRING, `"a"`)), nil, "expected an array"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fd := testFile(t, testMessage("Settings", nil, tt.field))
+			field := fd.Messages().ByName("Settings").Fields().Get(0)
+
+			schema, err := NewGenerator().generateFieldSchema(field, field.Options().(*descriptorpb.FieldOptions))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) || !strings.Contains(err.Error(), string(field.FullName())) {
+					t.Fatalf("expected error containing %q and the field name, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("generateFieldSchema failed: %v", err)
+			}
+			if schema["default"] != tt.want {
+				t.Errorf("default = %#v, want %#v", schema["default"], tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldDefault_EnumAndTimestamp(t *testing.T) {
+	fd := testFileWithEnums(t,
+		[]*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Level"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("LEVEL_UNSPECIFIED"), Number: proto.Int32(0)},
+				{Name: proto.String("LEVEL_HIGH"), Number: proto.Int32(1)},
+			},
+		}},
+		testMessage("Settings", nil,
+			&descriptorpb.FieldDescriptorProto{
+				Name:     proto.String("level"),
+				Number:   proto.Int32(1),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_ENUM.Enum(),
+				TypeName: proto.String(".test.Level"),
+				Options:  fieldOpts(ext(jsonschemapb.E_Default, `"LEVEL_LOW"`)),
+			},
+			testMessageField("since", 2, ".google.protobuf.Timestamp", fieldOpts(ext(jsonschemapb.E_Default, `"2024-01-02T03:04:05Z"`))),
+		),
+	)
+	md := fd.Messages().ByName("Settings")
+	g := NewGenerator()
+
+	level := md.Fields().ByName("level")
+	if _, err := g.generateFieldSchema(level, level.Options().(*descriptorpb.FieldOptions)); err == nil || !strings.Contains(err.Error(), "test.Settings.level") {
+		t.Errorf("expected unknown enum value default to fail, got %v", err)
+	}
+	since := md.Fields().ByName("since")
+	schema, err := g.generateFieldSchema(since, since.Options().(*descriptorpb.FieldOptions))
+	if err != nil {
+		t.Fatalf("generateFieldSchema failed: %v", err)
+	}
+	if schema["default"] != "2024-01-02T03:04:05Z" {
+		t.Errorf("expected timestamp default, got %v", schema["default"])
+	}
+}
+
+func TestFieldDefault_MappedTypeOnlyNeedsJSON(t *testing.T) {
+	fd := testFile(t,
+		testMessage("Money", nil, testField("units", 1, descriptorpb.FieldDescriptorProto_TYPE_INT64, nil)),
+		testMessage("Order", nil, testMessageField("total", 1, ".test.Money", fieldOpts(ext(jsonschemapb.E_Default, `"0.00"`)))),
+	)
+	g := NewGenerator()
+	g.RegisterTypeMapper("test.Money", StaticTypeMapper(Schema{"type": "string"}))
+	field := fd.Messages().ByName("Order").Fields().Get(0)
+	schema, err := g.generateFieldSchema(field, field.Options().(*descriptorpb.FieldOptions))
+	if err != nil {
+		t.Fatalf("generateFieldSchema failed: %v", err)
+	}
+	if schema["default"] != "0.00" {
+		t.Errorf("expected mapped default to be kept, got %v", schema["default"])
+	}
+}
+
+func TestApplyDefaults(t *testing.T) {
+	fd := testFile(t,
+		testMessage("Limits", nil,
+			testField("burst", 1, descriptorpb.FieldDescriptorProto_TYPE_INT64, fieldOpts(ext(jsonschemapb.E_Default, "9007199254740993"))),
+		),
+		testMessage("Settings", nil,
+			testField("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(ext(jsonschemapb.E_Default, `"guest"`))),
+			testField("retries", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, fieldOpts(ext(jsonschemapb.E_Default, "3"))),
+			repeated(testField("tags", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(ext(jsonschemapb.E_Default, `["a", "b"]`)))),
+			testMessageField("limits", 4, ".test.Limits", nil),
+			testMessageField("since", 5, ".google.protobuf.Timestamp", fieldOpts(ext(jsonschemapb.E_Default, `"2024-01-02T03:04:05Z"`))),
+		),
+	)
+	md := fd.Messages().ByName("Settings")
+	msg := dynamicpb.NewMessage(md)
+	msg.Set(md.Fields().ByName("retries"), protoreflect.ValueOfInt32(5))
+	msg.Mutable(md.Fields().ByName("limits"))
+
+	if err := ApplyDefaults(msg); err != nil {
+		t.Fatalf("ApplyDefaults failed: %v", err)
+	}
+
+	if got := msg.Get(md.Fields().ByName("name")).String(); got != "guest" {
+		t.Errorf("name = %q, want default", got)
+	}
+	if got := msg.Get(md.Fields().ByName("retries")).Int(); got != 5 {
+		t.Errorf("retries = %d, want the set value kept", got)
+	}
+	if got := msg.Get(md.Fields().ByName("tags")).List(); got.Len() != 2 || got.Get(1).String() != "b" {
+		t.Errorf("tags = %v, want default", got)
+	}
+	limits := msg.Get(md.Fields().ByName("limits")).Message()
+	if got := limits.Get(limits.Descriptor().Fields().ByName("burst")).Int(); got != 9007199254740993 {
+		t.Errorf("limits.burst = %d, want nested default", got)
+	}
+	since := msg.Get(md.Fields().ByName("since")).Message()
+	if got := since.Get(since.Descriptor().Fields().ByName("seconds")).Int(); got != 1704164645 {
+		t.Errorf("since.seconds = %d, want timestamp default", got)
+	}
+}
+
+func TestApplyDefaults_Oneof(t *testing.T) {
+	contact := testMessage("Contact", nil,
+		testField("email", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(ext(jsonschemapb.E_Default, `"x"`))),
+		testField("phone", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(ext(jsonschemapb.E_Default, `"y"`))),
+	)
+	contact.OneofDecl = []*descriptorpb.OneofDescriptorProto{{Name: proto.String("channel")}}
+	contact.Field[0].OneofIndex = proto.Int32(0)
+	contact.Field[1].OneofIndex = proto.Int32(0)
+	md := testFile(t, contact).Messages().ByName("Contact")
+	email, phone := md.Fields().ByName("email"), md.Fields().ByName("phone")
+
+	set := dynamicpb.NewMessage(md)
+	set.Set(phone, protoreflect.ValueOfString("set"))
+	if err := ApplyDefaults(set); err != nil {
+		t.Fatalf("ApplyDefaults failed: %v", err)
+	}
+	if set.Has(email) || set.Get(phone).String() != "set" {
+		t.Errorf("expected the set member to be kept, got email=%v phone=%q", set.Has(email), set.Get(phone).String())
+	}
+
+	unset := dynamicpb.NewMessage(md)
+	if err := ApplyDefaults(unset); err != nil {
+		t.Fatalf("ApplyDefaults failed: %v", err)
+	}
+	if unset.Get(email).String() != "x" || unset.Has(phone) {
+		t.Errorf("expected only the first member to be defaulted, got email=%q phone=%v", unset.Get(email).String(), unset.Has(phone))
+	}
+}
@@ -0,0 +1,31 @@
+package jsonschema
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// applyDeprecation marks schema as deprecated when the proto deprecated
+// option or a deprecation note is set, and appends the note to the
+// description so that readers of the schema see why the field or message
+// should no longer be used
+func applyDeprecation(schema Schema, deprecated bool, opts proto.Message, note protoreflect.ExtensionType) {
+	hasNote := proto.HasExtension(opts, note)
+	if !deprecated && !hasNote {
+		return
+	}
+	schema["deprecated"] = true
+	if hasNote {
+		if text := proto.GetExtension(opts, note).(string); text != "" {
+			appendDescription(schema, "Deprecated: "+text)
+		}
+	}
+}
+
+// appendDescription adds text as a new paragraph of the description of schema
+func appendDescription(schema Schema, text string) {
+	if description, ok := schema["description"].(string); ok && description != "" {
+		text = description + "\n\n" + text
+	}
+	schema["description"] = text
+}
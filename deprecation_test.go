@@ -0,0 +1,89 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+func TestDeprecation_FieldsAndMessage(t *testing.T) {
+	legacy := fieldOpts(ext(jsonschemapb.E_Description, "Legacy identifier"))
+	legacy.Deprecated = proto.Bool(true)
+	account := msgOpts(ext(jsonschemapb.E_MessageDeprecationNote, "use AccountV2"))
+	fd := testFile(t,
+		testMessage("Account", account,
+			testField("id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(
+				ext(jsonschemapb.E_FieldTitle, "Account ID"),
+				ext(jsonschemapb.E_Since, "v1.2"),
+			)),
+			testField("legacy_id", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, legacy),
+			testField("nick", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(
+				ext(jsonschemapb.E_Description, "Display name"),
+				ext(jsonschemapb.E_DeprecationNote, "use display_name instead"),
+				ext(jsonschemapb.E_RemovedIn, "v3"),
+			)),
+		),
+	)
+
+	schema, err := NewGenerator().GenerateSchema(fd.Messages().ByName("Account"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	m := mustSchemaMap(t, schema)
+	if m["deprecated"] != true || m["description"] != "Deprecated: use AccountV2" {
+		t.Errorf("expected deprecated message with note, got deprecated=%v description=%q", m["deprecated"], m["description"])
+	}
+
+	props := m["properties"].(map[string]interface{})
+	id := props["id"].(map[string]interface{})
+	if id["title"] != "Account ID" || id["x-since"] != "v1.2" {
+		t.Errorf("expected title and x-since on id, got %v", id)
+	}
+	if _, ok := id["deprecated"]; ok {
+		t.Errorf("expected id not to be deprecated, got %v", id)
+	}
+
+	legacyID := props["legacyId"].(map[string]interface{})
+	if legacyID["deprecated"] != true || legacyID["description"] != "Legacy identifier" {
+		t.Errorf("expected deprecated legacy_id with unchanged description, got %v", legacyID)
+	}
+
+	nick := props["nick"].(map[string]interface{})
+	if nick["deprecated"] != true {
+		t.Errorf("expected deprecation note to mark nick deprecated, got %v", nick)
+	}
+	if want := "Display name\n\nDeprecated: use display_name instead"; nick["description"] != want {
+		t.Errorf("expected description %q, got %q", want, nick["description"])
+	}
+	if nick["x-removed-in"] != "v3" {
+		t.Errorf("expected x-removed-in on nick, got %v", nick)
+	}
+}
+
+func TestDeprecation_OrderedSchema(t *testing.T) {
+	account := &descriptorpb.MessageOptions{Deprecated: proto.Bool(true)}
+	fd := testFile(t,
+		testMessage("Account", account,
+			testField("id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(ext(jsonschemapb.E_FieldTitle, "Account ID"))),
+		),
+	)
+
+	ordered, err := NewGeneratorWithOptions(true).GenerateOrderedSchema(fd.Messages().ByName("Account"))
+	if err != nil {
+		t.Fatalf("GenerateOrderedSchema failed: %v", err)
+	}
+	data, err := json.Marshal(ordered)
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+	for _, want := range []string{`"deprecated":true`, `"title":"Account ID"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %s in %s", want, data)
+		}
+	}
+}
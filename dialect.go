@@ -0,0 +1,259 @@
+package jsonschema
+
+import (
+	"strings"
+)
+
+// Dialect selects the JSON Schema draft generated schemas conform to
+type Dialect string
+
+// Supported dialects
+const (
+	DialectDraft04 Dialect = "draft-04"
+	DialectDraft07 Dialect = "draft-07"
+	Dialect201909  Dialect = "2019-09"
+	Dialect202012  Dialect = "2020-12"
+)
+
+// dialectURIs holds the $schema meta-schema URI of each dialect
+var dialectURIs = map[Dialect]string{
+	DialectDraft04: "http://json-schema.org/draft-04/schema#",
+	DialectDraft07: "http://json-schema.org/draft-07/schema#",
+	Dialect201909:  "https://json-schema.org/draft/2019-09/schema",
+	Dialect202012:  "https://json-schema.org/draft/2020-12/schema",
+}
+
+// SetDialect makes generated schemas declare dialect in $schema and use its
+// keywords. With no dialect (the default) schemas carry no $schema and are
+// left as generated.
+func (g *Generator) SetDialect(dialect Dialect) {
+	g.dialect = dialect
+}
+
+// Dialect returns the dialect set by SetDialect
+func (g *Generator) Dialect() Dialect {
+	return g.dialect
+}
+
+// IsValid reports whether d is one of the supported dialects
+func (d Dialect) IsValid() bool {
+	_, ok := dialectURIs[d]
+	return ok
+}
+
+// URI returns the $schema meta-schema URI of d, or "" for an unknown dialect
+func (d Dialect) URI() string {
+	return dialectURIs[d]
+}
+
+// applyDialect stamps $schema on a message schema and rewrites it and its
+// subschemas to the keywords of the generator's dialect
+func (g *Generator) applyDialect(schema Schema) {
+	if !g.dialect.IsValid() {
+		return
+	}
+	rewriteDialect(schema, g.dialect)
+	schema["$schema"] = g.dialect.URI()
+}
+
+// rewriteDialect rewrites the keywords of schema for dialect, then recurses
+// into its subschemas:
+//
+//   - example (OpenAPI) becomes examples, from draft-06 on
+//   - format: byte (OpenAPI) becomes contentEncoding: base64, from draft-07 on
+//   - $defs and definitions, and the $refs into them, follow the dialect
+//   - numeric exclusiveMinimum/exclusiveMaximum become draft-04's boolean
+//     modifiers of minimum/maximum
+//   - const becomes a single-value enum, and if/then/else an equivalent
+//     anyOf, in draft-04
+//   - dependentRequired/dependentSchemas become dependencies before 2019-09
+//   - tuple items/additionalItems become prefixItems/items in 2020-12, and
+//     back in earlier dialects
+func rewriteDialect(schema map[string]interface{}, dialect Dialect) {
+	draft04 := dialect == DialectDraft04
+	before201909 := draft04 || dialect == DialectDraft07
+
+	if example, ok := schema["example"]; ok && !draft04 {
+		examples, _ := schema["examples"].([]interface{})
+		schema["examples"] = append([]interface{}{example}, examples...)
+		delete(schema, "example")
+	}
+	if schema["format"] == "byte" && !draft04 {
+		delete(schema, "format")
+		schema["contentEncoding"] = "base64"
+	}
+
+	defsKeyword, otherDefs := "$defs", "definitions"
+	if before201909 {
+		defsKeyword, otherDefs = otherDefs, defsKeyword
+	}
+	if defs, ok := schema[otherDefs]; ok {
+		if existing, ok := asObject(schema[defsKeyword]); ok {
+			if moved, ok := asObject(defs); ok {
+				for name, def := range moved {
+					existing[name] = def
+				}
+				defs = existing
+			}
+		}
+		schema[defsKeyword] = defs
+		delete(schema, otherDefs)
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		schema["$ref"] = strings.Replace(ref, "#/"+otherDefs+"/", "#/"+defsKeyword+"/", 1)
+	}
+
+	if draft04 {
+		rewriteExclusiveBound(schema, "minimum", "exclusiveMinimum", func(a, b float64) bool { return a >= b })
+		rewriteExclusiveBound(schema, "maximum", "exclusiveMaximum", func(a, b float64) bool { return a <= b })
+		if value, ok := schema["const"]; ok {
+			schema["enum"] = []interface{}{value}
+			delete(schema, "const")
+		}
+		rewriteIfThenElse(schema)
+	}
+
+	if before201909 {
+		dependencies, _ := asObject(schema["dependencies"])
+		for _, keyword := range []string{"dependentRequired", "dependentSchemas"} {
+			deps, ok := asObject(schema[keyword])
+			if required, isList := schema[keyword].(map[string][]string); isList {
+				deps, ok = map[string]interface{}{}, true
+				for name, names := range required {
+					deps[name] = names
+				}
+			}
+			if !ok {
+				continue
+			}
+			if dependencies == nil {
+				dependencies = map[string]interface{}{}
+			}
+			for name, dep := range deps {
+				dependencies[name] = dep
+			}
+			delete(schema, keyword)
+		}
+		if dependencies != nil {
+			schema["dependencies"] = dependencies
+		}
+	}
+
+	if dialect == Dialect202012 {
+		if items, ok := schema["items"].([]interface{}); ok {
+			schema["prefixItems"] = items
+			delete(schema, "items")
+			if additional, ok := schema["additionalItems"]; ok {
+				schema["items"] = additional
+				delete(schema, "additionalItems")
+			}
+		}
+	} else if prefixItems, ok := schema["prefixItems"].([]interface{}); ok {
+		if items, ok := schema["items"]; ok {
+			schema["additionalItems"] = items
+		}
+		schema["items"] = prefixItems
+		delete(schema, "prefixItems")
+	}
+
+	forEachSubschema(schema, func(sub map[string]interface{}) {
+		rewriteDialect(sub, dialect)
+	})
+}
+
+// rewriteExclusiveBound turns a numeric exclusive bound into draft-04's
+// boolean form, keeping whichever of it and an inclusive bound is stricter
+func rewriteExclusiveBound(schema map[string]interface{}, inclusive, exclusive string, stricter func(a, b float64) bool) {
+	bound, ok := numberValue(schema[exclusive])
+	if !ok {
+		return
+	}
+	if current, ok := numberValue(schema[inclusive]); ok && !stricter(bound, current) {
+		delete(schema, exclusive)
+		return
+	}
+	schema[inclusive] = bound
+	schema[exclusive] = true
+}
+
+// rewriteIfThenElse expresses if/then/else, which draft-04 lacks, as
+// anyOf: [{allOf: [if, then]}, {allOf: [{not: if}, else]}]
+func rewriteIfThenElse(schema map[string]interface{}) {
+	condition, ok := schema["if"]
+	if !ok {
+		return
+	}
+	then, ok := schema["then"]
+	if !ok {
+		then = Schema{}
+	}
+	otherwise, ok := schema["else"]
+	if !ok {
+		otherwise = Schema{}
+	}
+	delete(schema, "if")
+	delete(schema, "then")
+	delete(schema, "else")
+
+	anyOf := []interface{}{
+		Schema{"allOf": []interface{}{condition, then}},
+		Schema{"allOf": []interface{}{Schema{"not": condition}, otherwise}},
+	}
+	if _, exists := schema["anyOf"]; exists {
+		allOf, _ := schema["allOf"].([]interface{})
+		schema["allOf"] = append(allOf, Schema{"anyOf": anyOf})
+		return
+	}
+	schema["anyOf"] = anyOf
+}
+
+// forEachSubschema calls fn with every subschema of schema that is a JSON
+// object, leaving out values such as enum, const and default
+func forEachSubschema(schema map[string]interface{}, fn func(map[string]interface{})) {
+	visit := func(value interface{}) {
+		if sub, ok := asObject(value); ok {
+			fn(sub)
+		}
+	}
+	for _, keyword := range []string{
+		"items", "additionalItems", "contains", "additionalProperties", "propertyNames",
+		"not", "if", "then", "else", "unevaluatedItems", "unevaluatedProperties",
+	} {
+		visit(schema[keyword])
+	}
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf", "prefixItems", "items"} {
+		if list, ok := schema[keyword].([]interface{}); ok {
+			for _, item := range list {
+				visit(item)
+			}
+		}
+	}
+	for _, keyword := range []string{
+		"properties", "patternProperties", "$defs", "definitions", "dependentSchemas", "dependencies",
+	} {
+		if subschemas, ok := asObject(schema[keyword]); ok {
+			for _, sub := range subschemas {
+				visit(sub)
+			}
+		}
+	}
+}
+
+// numberValue reads a JSON number of any of the Go types schemas hold
+func numberValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	}
+	return 0, false
+}
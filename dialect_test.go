@@ -0,0 +1,179 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+func dialectMessage(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+	fd := testFile(t,
+		testMessage("Ship", msgOpts(
+			ext(jsonschemapb.E_Conditional, []*jsonschemapb.ConditionalRule{{Field: "mode", Equals: "EXPRESS", ThenRequired: []string{"phone"}}}),
+			ext(jsonschemapb.E_DependentRequired, []*jsonschemapb.DependentRequired{{Field: "phone", Requires: []string{"name"}}}),
+		),
+			testField("mode", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(ext(jsonschemapb.E_Example, "EXPRESS"))),
+			testField("phone", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+			testField("name", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+			testField("blob", 4, descriptorpb.FieldDescriptorProto_TYPE_BYTES, nil),
+		),
+	)
+	return fd.Messages().ByName("Ship")
+}
+
+func dialectSchema(t *testing.T, dialect Dialect, md protoreflect.MessageDescriptor) map[string]interface{} {
+	t.Helper()
+	g := NewGenerator()
+	g.SetDialect(dialect)
+	schema, err := g.GenerateSchema(md)
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	return mustSchemaMap(t, schema)
+}
+
+func TestDialect_NoneLeavesSchemaAsGenerated(t *testing.T) {
+	m := dialectSchema(t, "", dialectMessage(t))
+	if _, ok := m["$schema"]; ok {
+		t.Errorf("expected no $schema without a dialect, got %v", m["$schema"])
+	}
+	mode := m["properties"].(map[string]interface{})["mode"].(map[string]interface{})
+	if mode["example"] != "EXPRESS" {
+		t.Errorf("expected example to be kept, got %v", mode)
+	}
+}
+
+func TestDialect_Keywords(t *testing.T) {
+	md := dialectMessage(t)
+
+	for _, dialect := range []Dialect{DialectDraft07, Dialect201909, Dialect202012} {
+		m := dialectSchema(t, dialect, md)
+		if m["$schema"] != dialect.URI() {
+			t.Errorf("%s: $schema = %v", dialect, m["$schema"])
+		}
+		props := m["properties"].(map[string]interface{})
+		mode := props["mode"].(map[string]interface{})
+		if _, ok := mode["example"]; ok || !reflect.DeepEqual(mode["examples"], []interface{}{"EXPRESS"}) {
+			t.Errorf("%s: expected example rewritten to examples, got %v", dialect, mode)
+		}
+		blob := props["blob"].(map[string]interface{})
+		if _, ok := blob["format"]; ok || blob["contentEncoding"] != "base64" {
+			t.Errorf("%s: expected format byte rewritten to contentEncoding, got %v", dialect, blob)
+		}
+		if _, ok := m["if"]; !ok {
+			t.Errorf("%s: expected if/then to be kept", dialect)
+		}
+	}
+
+	draft07 := dialectSchema(t, DialectDraft07, md)
+	if _, ok := draft07["dependentRequired"]; ok {
+		t.Errorf("draft-07: expected dependentRequired rewritten, got %v", draft07)
+	}
+	if !reflect.DeepEqual(draft07["dependencies"], map[string]interface{}{"phone": []interface{}{"name"}}) {
+		t.Errorf("draft-07: dependencies = %v", draft07["dependencies"])
+	}
+	if _, ok := dialectSchema(t, Dialect201909, md)["dependentRequired"]; !ok {
+		t.Error("2019-09: expected dependentRequired to be kept")
+	}
+}
+
+func TestDialect_Draft04(t *testing.T) {
+	m := dialectSchema(t, DialectDraft04, dialectMessage(t))
+	if m["$schema"] != "http://json-schema.org/draft-04/schema#" {
+		t.Errorf("$schema = %v", m["$schema"])
+	}
+	props := m["properties"].(map[string]interface{})
+	if props["mode"].(map[string]interface{})["example"] != "EXPRESS" {
+		t.Errorf("expected example kept in draft-04, got %v", props["mode"])
+	}
+	for _, keyword := range []string{"if", "then", "const"} {
+		data, _ := json.Marshal(m)
+		if strings.Contains(string(data), `"`+keyword+`"`) {
+			t.Errorf("expected no %s keyword in draft-04 schema %s", keyword, data)
+		}
+	}
+	anyOf, ok := m["anyOf"].([]interface{})
+	if !ok || len(anyOf) != 2 {
+		t.Fatalf("expected if/then rewritten to a two-branch anyOf, got %v", m["anyOf"])
+	}
+	data, _ := json.Marshal(anyOf[0])
+	if want := `{"allOf":[{"properties":{"mode":{"enum":["EXPRESS"]}},"required":["mode"]},{"required":["phone"]}]}`; string(data) != want {
+		t.Errorf("anyOf[0] = %s, want %s", data, want)
+	}
+}
+
+func TestRewriteDialect_Subschemas(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		in      string
+		want    string
+	}{
+		{
+			"exclusive bounds in draft-04", DialectDraft04,
+			`{"properties":{"n":{"exclusiveMinimum":0,"minimum":-5,"exclusiveMaximum":10,"maximum":3}}}`,
+			`{"properties":{"n":{"exclusiveMinimum":true,"maximum":3,"minimum":0}}}`,
+		},
+		{
+			"defs in draft-07", DialectDraft07,
+			`{"$defs":{"A":{"type":"string"}},"properties":{"a":{"$ref":"#/$defs/A"}}}`,
+			`{"definitions":{"A":{"type":"string"}},"properties":{"a":{"$ref":"#/definitions/A"}}}`,
+		},
+		{
+			"definitions in 2020-12", Dialect202012,
+			`{"definitions":{"A":{"type":"string"}},"items":{"$ref":"#/definitions/A"}}`,
+			`{"$defs":{"A":{"type":"string"}},"items":{"$ref":"#/$defs/A"}}`,
+		},
+		{
+			"tuples in 2020-12", Dialect202012,
+			`{"properties":{"p":{"items":[{"type":"number"},{"type":"number"}],"additionalItems":false}}}`,
+			`{"properties":{"p":{"items":false,"prefixItems":[{"type":"number"},{"type":"number"}]}}}`,
+		},
+		{
+			"tuples in 2019-09", Dialect201909,
+			`{"prefixItems":[{"type":"number"}],"items":{"type":"string"}}`,
+			`{"additionalItems":{"type":"string"},"items":[{"type":"number"}]}`,
+		},
+		{
+			"property named like a keyword", DialectDraft04,
+			`{"properties":{"const":{"const":1}},"enum":[{"const":2}]}`,
+			`{"enum":[{"const":2}],"properties":{"const":{"enum":[1]}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var schema map[string]interface{}
+			if err := json.Unmarshal([]byte(tt.in), &schema); err != nil {
+				t.Fatal(err)
+			}
+			rewriteDialect(schema, tt.dialect)
+			data, _ := json.Marshal(schema)
+			if string(data) != tt.want {
+				t.Errorf("got %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestDialect_OrderedSchemaWritesSchemaFirst(t *testing.T) {
+	g := NewGeneratorWithOptions(true)
+	g.SetDialect(Dialect202012)
+	ordered, err := g.GenerateOrderedSchema(dialectMessage(t))
+	if err != nil {
+		t.Fatalf("GenerateOrderedSchema failed: %v", err)
+	}
+	data, err := json.Marshal(ordered)
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+	if want := `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object"`; !strings.HasPrefix(string(data), want) {
+		t.Errorf("expected output to start with %s, got %s", want, data)
+	}
+}
@@ -0,0 +1,122 @@
+package jsonschema
+
+import (
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// elicitationKeywords are the keywords MCP elicitation accepts on each
+// primitive type of a requestedSchema property
+var elicitationKeywords = map[string]map[string]bool{
+	"string":  {"type": true, "title": true, "description": true, "minLength": true, "maxLength": true, "format": true, "enum": true, "enumNames": true},
+	"number":  {"type": true, "title": true, "description": true, "minimum": true, "maximum": true},
+	"integer": {"type": true, "title": true, "description": true, "minimum": true, "maximum": true},
+	"boolean": {"type": true, "title": true, "description": true, "default": true},
+}
+
+// elicitationFormats are the string formats MCP elicitation accepts
+var elicitationFormats = map[string]bool{"email": true, "uri": true, "date": true, "date-time": true}
+
+// checkElicitationFields reports the first visible field of md that cannot be
+// a property of an elicitation form: repeated fields, maps, oneof members and
+// message fields other than google.protobuf.Timestamp and mapped types
+func (g *Generator) checkElicitationFields(md protoreflect.MessageDescriptor) error {
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if !g.isFieldVisible(field) {
+			continue
+		}
+		switch {
+		case field.IsMap():
+			return fmt.Errorf("%s: map fields are not allowed in elicitation forms", field.FullName())
+		case field.IsList():
+			return fmt.Errorf("%s: repeated fields are not allowed in elicitation forms", field.FullName())
+		case field.ContainingOneof() != nil && !field.ContainingOneof().IsSynthetic():
+			return fmt.Errorf("%s: oneof fields are not allowed in elicitation forms", field.FullName())
+		case field.Kind() == protoreflect.MessageKind || field.Kind() == protoreflect.GroupKind:
+			if _, mapped := g.typeMapper(field); !mapped && field.Message().FullName() != "google.protobuf.Timestamp" {
+				return fmt.Errorf("%s: nested message %s is not allowed in elicitation forms", field.FullName(), field.Message().FullName())
+			}
+		}
+	}
+	return nil
+}
+
+// elicitationSchema rewrites schema into the shape of an MCP elicitation
+// requestedSchema: an object with primitive properties and required. Timestamps
+// become date-time strings; keywords and formats elicitation does not accept
+// are dropped and reported through warn. Properties that are not primitive,
+// such as those produced by type mappers or schema_json, are an error.
+func elicitationSchema(schema Schema, warn func(string)) (Schema, error) {
+	form := Schema{"type": "object"}
+	properties, _ := asObject(schema["properties"])
+	formProperties := Schema{}
+	for name, property := range properties {
+		sub, _ := asObject(property)
+		path := "#/properties/" + name
+		formProperty, err := elicitationProperty(sub, path, warn)
+		if err != nil {
+			return nil, err
+		}
+		formProperties[name] = formProperty
+	}
+	form["properties"] = formProperties
+	if required, ok := stringList(schema["required"]); ok && len(required) > 0 {
+		form["required"] = required
+	}
+
+	var dropped []string
+	for keyword := range schema {
+		switch keyword {
+		case "type", "properties", "required", "title", "description", "additionalProperties":
+		default:
+			dropped = append(dropped, keyword)
+		}
+	}
+	sort.Strings(dropped)
+	for _, keyword := range dropped {
+		warn(fmt.Sprintf("#: dropped unsupported keyword %s", keyword))
+	}
+	return form, nil
+}
+
+// elicitationProperty rewrites a property schema, found at path, into one of
+// the primitive schemas of MCP elicitation
+func elicitationProperty(schema map[string]interface{}, path string, warn func(string)) (Schema, error) {
+	if oneOf, ok := schema["oneOf"].([]interface{}); ok {
+		if timestamp, ok := dateTimeBranch(oneOf); ok {
+			delete(schema, "oneOf")
+			for _, key := range []string{"type", "format"} {
+				schema[key] = timestamp[key]
+			}
+		}
+	}
+
+	typ, _ := schema["type"].(string)
+	allowed, ok := elicitationKeywords[typ]
+	if !ok {
+		return nil, fmt.Errorf("%s: elicitation forms only accept string, number, integer and boolean properties", path)
+	}
+
+	property := Schema{}
+	var dropped []string
+	for keyword, value := range schema {
+		if !allowed[keyword] {
+			dropped = append(dropped, keyword)
+			continue
+		}
+		property[keyword] = value
+	}
+	sort.Strings(dropped)
+	for _, keyword := range dropped {
+		warn(fmt.Sprintf("%s: dropped unsupported keyword %s", path, keyword))
+	}
+	if format, ok := property["format"].(string); ok && !elicitationFormats[format] {
+		warn(fmt.Sprintf("%s: dropped unsupported format %q", path, format))
+		delete(property, "format")
+	}
+	return property, nil
+}
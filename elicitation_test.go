@@ -0,0 +1,107 @@
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+func elicitationGenerator(warnings *[]string) *Generator {
+	g := NewGenerator()
+	g.SetProfile(ProfileMCPElicitation)
+	g.SetWarningHandler(func(md protoreflect.MessageDescriptor, warning string) {
+		*warnings = append(*warnings, warning)
+	})
+	return g
+}
+
+func TestMCPElicitation_Form(t *testing.T) {
+	plan := testField("plan", 5, tEnum, nil)
+	plan.TypeName = proto.String(".test.Plan")
+	fd := testFileWithEnums(t, []*descriptorpb.EnumDescriptorProto{{
+		Name: proto.String("Plan"),
+		Value: []*descriptorpb.EnumValueDescriptorProto{
+			{Name: proto.String("FREE"), Number: proto.Int32(0)},
+			{Name: proto.String("PRO"), Number: proto.Int32(1)},
+		},
+	}},
+		testMessage("Signup", msgOpts(ext(jsonschemapb.E_Title, "Sign up")),
+			testField("email", 1, tString, fieldOpts(
+				ext(jsonschemapb.E_Format, "email"), ext(jsonschemapb.E_Required, true), ext(jsonschemapb.E_MaxLength, int32(100)))),
+			testField("nickname", 2, tString, fieldOpts(ext(jsonschemapb.E_Pattern, "^[a-z]+$"), ext(jsonschemapb.E_Format, "hostname"))),
+			testField("age", 3, tInt32, fieldOpts(ext(jsonschemapb.E_Minimum, 13.0))),
+			testField("newsletter", 4, tBool, fieldOpts(ext(jsonschemapb.E_Default, "true"))),
+			plan,
+			testMessageField("birthday", 6, ".google.protobuf.Timestamp", nil),
+			testMessageField("address", 7, ".test.Address", fieldOpts(ext(jsonschemapb.E_Hidden, true))),
+		),
+		testMessage("Address", nil, testField("city", 1, tString, nil)),
+	)
+
+	var warnings []string
+	schema, err := elicitationGenerator(&warnings).GenerateSchema(fd.Messages().ByName("Signup"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	assertKeywords(t, mustSchemaMap(t, schema), `{"properties":{`+
+		`"age":{"minimum":13,"type":"integer"},`+
+		`"birthday":{"format":"date-time","type":"string"},`+
+		`"email":{"format":"email","maxLength":100,"type":"string"},`+
+		`"newsletter":{"default":true,"type":"boolean"},`+
+		`"nickname":{"type":"string"},`+
+		`"plan":{"enum":["FREE","PRO"],"type":"string"}},`+
+		`"required":["email"],"type":"object"}`)
+
+	want := `#/properties/nickname: dropped unsupported keyword pattern|#/properties/nickname: dropped unsupported format "hostname"`
+	if got := strings.Join(warnings, "|"); got != want {
+		t.Errorf("unexpected warnings\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestMCPElicitation_Rejected(t *testing.T) {
+	entry := testMessage("TagsEntry", &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+		testField("key", 1, tString, nil),
+		testField("value", 2, tString, nil),
+	)
+	tagged := testMessage("Tagged", nil, repeated(testMessageField("tags", 1, ".test.Tagged.TagsEntry", nil)))
+	tagged.NestedType = []*descriptorpb.DescriptorProto{entry}
+
+	choice := testMessage("Choice", nil,
+		testField("email", 1, tString, nil),
+		testField("phone", 2, tString, nil),
+	)
+	choice.OneofDecl = []*descriptorpb.OneofDescriptorProto{{Name: proto.String("contact")}}
+	choice.Field[0].OneofIndex = proto.Int32(0)
+	choice.Field[1].OneofIndex = proto.Int32(0)
+
+	fd := testFile(t,
+		testMessage("Nested", nil, testMessageField("address", 1, ".test.Address", nil)),
+		testMessage("Listed", nil, repeated(testField("names", 1, tString, nil))),
+		tagged,
+		choice,
+		testMessage("Raw", nil, testField("blob", 1, tString, fieldOpts(ext(jsonschemapb.E_SchemaJson, `{"type": "object"}`), ext(jsonschemapb.E_SchemaJsonReplace, true)))),
+		testMessage("Address", nil, testField("city", 1, tString, nil)),
+	)
+
+	for _, tc := range []struct {
+		message protoreflect.Name
+		want    string
+	}{
+		{"Nested", "test.Nested: mcp_elicitation profile: test.Nested.address: nested message test.Address is not allowed in elicitation forms"},
+		{"Listed", "test.Listed.names: repeated fields are not allowed in elicitation forms"},
+		{"Tagged", "test.Tagged.tags: map fields are not allowed in elicitation forms"},
+		{"Choice", "test.Choice.email: oneof fields are not allowed in elicitation forms"},
+		{"Raw", "#/properties/blob: elicitation forms only accept string, number, integer and boolean properties"},
+	} {
+		var warnings []string
+		_, err := elicitationGenerator(&warnings).GenerateSchema(fd.Messages().ByName(tc.message))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.message, tc.want, err)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package jsonschema
 
 import (
+	"strings"
 	"testing"
 
 	"google.golang.org/protobuf/proto"
@@ -26,7 +27,10 @@ func TestGenerateFieldSchema_NumericAndStringOptions(t *testing.T) {
 	proto.SetExtension(opts, jsonschemapb.E_Pattern, "^[0-9]+$")
 	proto.SetExtension(opts, jsonschemapb.E_Default, "0")
 
-	schema := g.generateFieldSchema(field, opts)
+	schema, err := g.generateFieldSchema(field, opts)
+	if err != nil {
+		t.Fatalf("generateFieldSchema failed: %v", err)
+	}
 
 	if schema["type"] != "integer" {
 		t.Errorf("expected integer type, got %v", schema["type"])
@@ -97,8 +101,8 @@ func TestGenerateSchema_DisabledReturnsNil(t *testing.T) {
 	opts := &descriptorpb.FieldOptions{}
 	proto.SetExtension(opts, jsonschemapb.E_Default, "not-valid-json{")
 
-	schema := g.generateFieldSchema(field, opts)
-	if _, ok := schema["default"]; ok {
-		t.Error("invalid JSON default should be skipped, not set")
+	_, err := g.generateFieldSchema(field, opts)
+	if err == nil || !strings.Contains(err.Error(), "google.protobuf.Timestamp.seconds: invalid default") {
+		t.Errorf("expected invalid JSON default to fail naming the field, got %v", err)
 	}
 }
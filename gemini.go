@@ -0,0 +1,183 @@
+package jsonschema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// geminiKeywords are the fields of Gemini's function declaration Schema, an
+// OpenAPI 3.0 subset; other keywords are dropped with a warning
+var geminiKeywords = map[string]bool{
+	"type": true, "format": true, "title": true, "description": true, "nullable": true,
+	"enum": true, "properties": true, "required": true, "minProperties": true, "maxProperties": true,
+	"items": true, "minItems": true, "maxItems": true, "minLength": true, "maxLength": true,
+	"pattern": true, "minimum": true, "maximum": true, "example": true, "default": true, "anyOf": true,
+}
+
+// geminiFormats are the formats Gemini accepts for each type
+var geminiFormats = map[string]map[string]bool{
+	"string":  {"date-time": true, "enum": true},
+	"integer": {"int32": true, "int64": true},
+	"number":  {"float": true, "double": true},
+}
+
+// geminiSchema rewrites schema, found at path, and its subschemas for Gemini
+// function declarations:
+//
+//   - the google.protobuf.Timestamp oneOf becomes its RFC3339 string form,
+//     and other oneOfs become anyOf
+//   - "null" types and null anyOf branches become nullable: true
+//   - enums are kept on strings only, with format: enum
+//   - examples becomes a single example, const a single-value enum
+//   - unsupported keywords and formats are dropped, reported through warn
+//   - type names are uppercased, as Gemini's Type enum spells them
+func geminiSchema(schema map[string]interface{}, path string, warn func(string)) {
+	if oneOf, ok := schema["oneOf"].([]interface{}); ok {
+		delete(schema, "oneOf")
+		if timestamp, ok := dateTimeBranch(oneOf); ok {
+			for _, key := range []string{"type", "format", "description"} {
+				if _, exists := schema[key]; !exists {
+					schema[key] = timestamp[key]
+				}
+			}
+		} else if _, exists := schema["anyOf"]; !exists {
+			schema["anyOf"] = oneOf
+		}
+	}
+	if examples, ok := schema["examples"].([]interface{}); ok {
+		if _, exists := schema["example"]; !exists && len(examples) > 0 {
+			schema["example"] = examples[0]
+		}
+		delete(schema, "examples")
+	}
+	if value, ok := schema["const"]; ok {
+		if _, exists := schema["enum"]; !exists {
+			schema["enum"] = []interface{}{value}
+		}
+		delete(schema, "const")
+	}
+
+	if types, ok := schema["type"].([]interface{}); ok {
+		var kept []interface{}
+		for _, t := range types {
+			if t == "null" {
+				schema["nullable"] = true
+			} else {
+				kept = append(kept, t)
+			}
+		}
+		if len(kept) > 1 {
+			warn(fmt.Sprintf("%s: kept type %v of %v", path, kept[0], kept))
+		}
+		if len(kept) > 0 {
+			schema["type"] = kept[0]
+		} else {
+			delete(schema, "type")
+		}
+	}
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok {
+		var kept []interface{}
+		for _, branch := range anyOf {
+			if sub, ok := asObject(branch); ok && len(sub) == 1 && sub["type"] == "null" {
+				schema["nullable"] = true
+				continue
+			}
+			kept = append(kept, branch)
+		}
+		delete(schema, "anyOf")
+		if len(kept) == 1 {
+			// a single remaining branch is the schema itself
+			if sub, ok := asObject(kept[0]); ok {
+				for key, value := range sub {
+					if _, exists := schema[key]; !exists {
+						schema[key] = value
+					}
+				}
+			}
+		} else if len(kept) > 1 {
+			schema["anyOf"] = kept
+		}
+	}
+
+	typ, _ := schema["type"].(string)
+	if names, ok := schema["enum"].([]string); ok {
+		enum := make([]interface{}, len(names))
+		for i, name := range names {
+			enum[i] = name
+		}
+		schema["enum"] = enum
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		var values []interface{}
+		onlyStrings := typ == "string"
+		for _, value := range enum {
+			switch value.(type) {
+			case nil:
+				schema["nullable"] = true
+			case string:
+				values = append(values, value)
+			default:
+				onlyStrings = false
+			}
+		}
+		if !onlyStrings {
+			warn(fmt.Sprintf("%s: dropped enum, which Gemini only accepts on strings", path))
+			delete(schema, "enum")
+		} else {
+			schema["enum"] = values
+			schema["format"] = "enum"
+		}
+	}
+	if format, ok := schema["format"].(string); ok && !geminiFormats[typ][format] {
+		warn(fmt.Sprintf("%s: dropped unsupported format %q", path, format))
+		delete(schema, "format")
+	}
+
+	var dropped []string
+	for keyword := range schema {
+		if !geminiKeywords[keyword] {
+			dropped = append(dropped, keyword)
+		}
+	}
+	sort.Strings(dropped)
+	for _, keyword := range dropped {
+		// objects are closed for Gemini anyway, so additionalProperties: false loses nothing
+		if keyword != "additionalProperties" || schema[keyword] != false {
+			warn(fmt.Sprintf("%s: dropped unsupported keyword %s", path, keyword))
+		}
+		delete(schema, keyword)
+	}
+
+	if properties, ok := asObject(schema["properties"]); ok {
+		for name, property := range properties {
+			if sub, ok := asObject(property); ok {
+				geminiSchema(sub, path+"/properties/"+name, warn)
+			}
+		}
+	}
+	if items, ok := asObject(schema["items"]); ok {
+		geminiSchema(items, path+"/items", warn)
+	}
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok {
+		for i, branch := range anyOf {
+			if sub, ok := asObject(branch); ok {
+				geminiSchema(sub, fmt.Sprintf("%s/anyOf/%d", path, i), warn)
+			}
+		}
+	}
+	if typ != "" {
+		schema["type"] = strings.ToUpper(typ)
+	}
+}
+
+// dateTimeBranch returns the RFC3339 string branch of a oneOf, such as the
+// one generated for google.protobuf.Timestamp
+func dateTimeBranch(oneOf []interface{}) (map[string]interface{}, bool) {
+	for _, branch := range oneOf {
+		if sub, ok := asObject(branch); ok && sub["type"] == "string" && sub["format"] == "date-time" {
+			return sub, true
+		}
+	}
+	return nil, false
+}
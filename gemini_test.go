@@ -0,0 +1,95 @@
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+// geminiGenerator returns a generator with the gemini profile that collects
+// its warnings into warnings
+func geminiGenerator(warnings *[]string) *Generator {
+	g := NewGenerator()
+	g.SetProfile(ProfileGemini)
+	g.SetStrictObjects(true)
+	g.SetWarningHandler(func(md protoreflect.MessageDescriptor, warning string) {
+		*warnings = append(*warnings, string(md.FullName())+": "+warning)
+	})
+	return g
+}
+
+func TestGemini_Rewrite(t *testing.T) {
+	level := testField("level", 4, tEnum, nil)
+	level.TypeName = proto.String(".test.Level")
+	fd := testFileWithEnums(t, []*descriptorpb.EnumDescriptorProto{{
+		Name: proto.String("Level"),
+		Value: []*descriptorpb.EnumValueDescriptorProto{
+			{Name: proto.String("LEVEL_UNSPECIFIED"), Number: proto.Int32(0)},
+			{Name: proto.String("HIGH"), Number: proto.Int32(1)},
+		},
+	}},
+		testMessage("Event", msgOpts(ext(jsonschemapb.E_MessageExample, []string{`{"id": "e1"}`})),
+			testField("id", 1, tString, fieldOpts(ext(jsonschemapb.E_Format, "uuid"), ext(jsonschemapb.E_Required, true))),
+			testMessageField("at", 2, ".google.protobuf.Timestamp", fieldOpts(ext(jsonschemapb.E_Description, "When it happens"))),
+			testMessageField("place", 3, ".test.Place", nil),
+			level,
+			testField("score", 5, tDouble, fieldOpts(ext(jsonschemapb.E_SchemaJson, `{"type": ["number", "null"], "exclusiveMinimum": 0}`))),
+			testField("rank", 6, tInt32, fieldOpts(ext(jsonschemapb.E_SchemaJson, `{"enum": [1, 2, 3]}`))),
+		),
+		testMessage("Place", nil, testField("name", 1, tString, nil)),
+	)
+
+	var warnings []string
+	schema, err := geminiGenerator(&warnings).GenerateSchema(fd.Messages().ByName("Event"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	m := mustSchemaMap(t, schema)
+
+	if m["type"] != "OBJECT" || m["example"] == nil {
+		t.Errorf("expected uppercase type and a single example, got %v / %v", m["type"], m["example"])
+	}
+	if _, ok := m["additionalProperties"]; ok {
+		t.Error("expected additionalProperties to be dropped")
+	}
+
+	props := m["properties"].(map[string]interface{})
+	assertKeywords(t, props["id"], `{"type":"STRING"}`)
+	assertKeywords(t, props["at"], `{"description":"When it happens","format":"date-time","type":"STRING"}`)
+	assertKeywords(t, props["place"], `{"properties":{"name":{"type":"STRING"}},"title":"Place","type":"OBJECT"}`)
+	assertKeywords(t, props["level"], `{"enum":["LEVEL_UNSPECIFIED","HIGH"],"format":"enum","type":"STRING"}`)
+	assertKeywords(t, props["score"], `{"nullable":true,"type":"NUMBER"}`)
+	assertKeywords(t, props["rank"], `{"type":"INTEGER"}`)
+
+	want := []string{
+		`test.Event: #/properties/id: dropped unsupported format "uuid"`,
+		`test.Event: #/properties/rank: dropped enum, which Gemini only accepts on strings`,
+		`test.Event: #/properties/score: dropped unsupported keyword exclusiveMinimum`,
+	}
+	for _, w := range want {
+		found := false
+		for _, got := range warnings {
+			found = found || got == w
+		}
+		if !found {
+			t.Errorf("expected warning %q, got %v", w, warnings)
+		}
+	}
+	if len(warnings) != len(want) {
+		t.Errorf("expected %d warnings, got %v", len(want), warnings)
+	}
+}
+
+func TestGemini_Recursion(t *testing.T) {
+	fd := testFile(t, testMessage("Node", nil, testMessageField("next", 1, ".test.Node", nil)))
+	var warnings []string
+	_, err := geminiGenerator(&warnings).GenerateSchema(fd.Messages().ByName("Node"))
+	if err == nil || !strings.Contains(err.Error(), "gemini profile: recursive message test.Node") {
+		t.Errorf("expected recursion error, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package jsonschema
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"google.golang.org/protobuf/proto"
@@ -17,7 +18,22 @@ type Schema map[string]interface{}
 
 // Generator generates JSON Schema from protobuf messages
 type Generator struct {
-	preserveOrder bool
+	preserveOrder  bool
+	partial        bool
+	strictObjects  bool
+	typeMappers    map[protoreflect.FullName]TypeMapper
+	pgv            bool
+	openapiv2      bool
+	fieldHooks     []FieldHook
+	messageHooks   []MessageHook
+	locale         string
+	audience       string
+	naming         NamingStrategy
+	dialect        Dialect
+	refPrefix      string
+	nullable       bool
+	profile        Profile
+	warningHandler WarningHandler
 }
 
 // NewGenerator creates a new Generator
@@ -42,73 +58,148 @@ func (g *Generator) IsPreserveOrder() bool {
 	return g.preserveOrder
 }
 
-// GenerateSchema generates JSON Schema for a message descriptor
-func (g *Generator) GenerateSchema(md protoreflect.MessageDescriptor) (Schema, error) {
-	msgOpts := md.Options().(*descriptorpb.MessageOptions)
+// SetPartial sets whether every message is generated as a partial (update)
+// schema, as if it carried the partial message option
+func (g *Generator) SetPartial(partial bool) {
+	g.partial = partial
+}
 
-	if !g.shouldGenerateSchema(msgOpts) {
-		return nil, nil
-	}
+// IsPartial returns whether partial schema generation is enabled for all messages
+func (g *Generator) IsPartial() bool {
+	return g.partial
+}
 
-	schema := g.createBaseSchema(md, msgOpts)
-	properties, required := g.processFields(md.Fields())
+// SetStrictObjects sets whether message schemas default to
+// additionalProperties: false when the message does not set the
+// additional_properties option itself
+func (g *Generator) SetStrictObjects(strict bool) {
+	g.strictObjects = strict
+}
 
-	schema["properties"] = properties
-	if len(required) > 0 {
-		schema["required"] = required
-	}
+// IsStrictObjects returns whether message schemas default to rejecting unknown properties
+func (g *Generator) IsStrictObjects() bool {
+	return g.strictObjects
+}
 
-	return schema, nil
+// SetPGV sets whether protoc-gen-validate (validate.rules) field rules are
+// translated into schema keywords for fields without protovalidate rules
+func (g *Generator) SetPGV(pgv bool) {
+	g.pgv = pgv
+}
+
+// IsPGV returns whether protoc-gen-validate rules are translated
+func (g *Generator) IsPGV() bool {
+	return g.pgv
+}
+
+// SetOpenAPIv2 sets whether grpc-gateway openapiv2_field and openapiv2_schema
+// annotations are used as a fallback source of schema metadata
+func (g *Generator) SetOpenAPIv2(openapiv2 bool) {
+	g.openapiv2 = openapiv2
+}
+
+// IsOpenAPIv2 returns whether openapiv2 annotations are used as a fallback
+func (g *Generator) IsOpenAPIv2() bool {
+	return g.openapiv2
+}
+
+// GenerateSchema generates JSON Schema for a message descriptor
+func (g *Generator) GenerateSchema(md protoreflect.MessageDescriptor) (Schema, error) {
+	schema, _, err := g.buildSchema(md)
+	return schema, err
 }
 
 // GenerateOrderedSchema generates an ordered JSON Schema for a message descriptor
 func (g *Generator) GenerateOrderedSchema(md protoreflect.MessageDescriptor) (*OrderedSchema, error) {
+	schema, order, err := g.buildSchema(md)
+	if err != nil || schema == nil {
+		return nil, err
+	}
+	return newOrderedSchema(schema, order), nil
+}
+
+// buildSchema generates the schema of md along with its property names in
+// field order, so the map and ordered schema paths share a single pipeline. A
+// nil schema means generation is disabled for md.
+func (g *Generator) buildSchema(md protoreflect.MessageDescriptor) (Schema, []string, error) {
+	if g.profile != "" && g.refPrefix == "" {
+		// generate message fields as references for the profile to inline
+		gen := *g
+		gen.refPrefix = profileRefPrefix
+		return gen.buildSchema(md)
+	}
 	msgOpts := md.Options().(*descriptorpb.MessageOptions)
 
-	if !g.shouldGenerateSchema(msgOpts) {
-		return nil, nil
+	if !g.shouldGenerateSchema(msgOpts) || !g.isAudienceVisible(msgOpts, jsonschemapb.E_MessageAudience) {
+		return nil, nil, nil
 	}
 
-	orderedSchema := &OrderedSchema{
-		Type:       "object",
-		Title:      g.getSchemaTitle(md, msgOpts),
-		Properties: []OrderedProperty{},
-		Required:   []string{},
+	schema, err := g.createBaseSchema(md, msgOpts)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if proto.HasExtension(msgOpts, jsonschemapb.E_MessageDescription) {
-		orderedSchema.Description = proto.GetExtension(msgOpts, jsonschemapb.E_MessageDescription).(string)
+	fields, err := g.processFields(md.Fields(), g.newPartialScope(md, msgOpts))
+	if err != nil {
+		return nil, nil, err
 	}
+	fields.apply(schema)
 
-	// Process fields in order
-	g.forEachVisibleField(md.Fields(), func(name string, fieldSchema Schema, required bool) {
-		orderedSchema.Properties = append(orderedSchema.Properties, OrderedProperty{
-			Name:   name,
-			Schema: fieldSchema,
-		})
-		if required {
-			orderedSchema.Required = append(orderedSchema.Required, name)
-		}
-	})
+	schema, err = applySchemaJSON(schema, msgOpts, jsonschemapb.E_MessageSchemaJson, jsonschemapb.E_MessageSchemaJsonReplace)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: invalid message_schema_json: %w", md.FullName(), err)
+	}
+
+	schema, err = g.runMessageHooks(md, schema)
+	if err != nil || schema == nil {
+		return nil, nil, err
+	}
+	if schema, err = g.applyProfile(md, schema, fields.order); err != nil {
+		return nil, nil, err
+	}
+	g.applyDialect(schema)
 
-	return orderedSchema, nil
+	return schema, fields.order, nil
 }
 
-// forEachVisibleField walks fields in descriptor order, skips hidden ones, and
-// invokes fn with each field's resolved JSON name, generated schema, and
-// required flag. Shared by the map and ordered schema paths so name resolution,
-// hidden-skip, and required detection cannot drift between them.
-func (g *Generator) forEachVisibleField(fields protoreflect.FieldDescriptors, fn func(name string, schema Schema, required bool)) {
+// forEachVisibleField walks fields in descriptor order, skips hidden ones and
+// those outside the generator's audience, and invokes fn with each field's
+// property names (see fieldNames), generated schema, and required flag. Shared by every
+// schema path so name resolution, hidden-skip, and required detection cannot
+// drift between them. A non-nil partial scope rewrites each field for a
+// partial (update) schema.
+func (g *Generator) forEachVisibleField(fields protoreflect.FieldDescriptors, partial *partialScope, fn func(names []string, schema Schema, required bool)) error {
 	for i := 0; i < fields.Len(); i++ {
 		field := fields.Get(i)
 		fieldOpts := field.Options().(*descriptorpb.FieldOptions)
 
-		if g.isFieldHidden(fieldOpts) {
+		if !g.isFieldVisible(field) {
+			continue
+		}
+
+		fieldSchema, err := g.generateFieldSchema(field, fieldOpts)
+		if err != nil {
+			return err
+		}
+		required := g.isFieldRequired(field, fieldOpts)
+		if partial != nil {
+			if err := g.applyPartial(field, fields, fieldSchema, partial); err != nil {
+				return err
+			}
+			required = false
+		}
+
+		fieldSchema, err = g.runFieldHooks(field, fieldSchema)
+		if err != nil {
+			return err
+		}
+		if fieldSchema == nil {
 			continue
 		}
 
-		fn(g.getFieldName(field, fieldOpts), g.generateFieldSchema(field, fieldOpts), g.isFieldRequired(fieldOpts))
+		fn(g.fieldNames(field, fieldOpts), fieldSchema, required)
 	}
+	return nil
 }
 
 // ShouldGenerateSchema reports whether schema generation is enabled for a
@@ -129,8 +220,10 @@ func (g *Generator) shouldGenerateSchema(msgOpts *descriptorpb.MessageOptions) b
 	return ShouldGenerateSchema(msgOpts)
 }
 
-// createBaseSchema creates the base schema with title and description
-func (g *Generator) createBaseSchema(md protoreflect.MessageDescriptor, msgOpts *descriptorpb.MessageOptions) Schema {
+// createBaseSchema creates the base schema with title, description,
+// deprecation, object constraints, conditional constraints, examples and
+// vendor extensions
+func (g *Generator) createBaseSchema(md protoreflect.MessageDescriptor, msgOpts *descriptorpb.MessageOptions) (Schema, error) {
 	schema := Schema{
 		"type":       "object",
 		"properties": make(map[string]interface{}),
@@ -142,7 +235,78 @@ func (g *Generator) createBaseSchema(md protoreflect.MessageDescriptor, msgOpts
 		schema["description"] = proto.GetExtension(msgOpts, jsonschemapb.E_MessageDescription).(string)
 	}
 
-	return schema
+	hasTitle := proto.HasExtension(msgOpts, jsonschemapb.E_Title)
+	if g.applyLocalized(schema, "title", msgOpts, jsonschemapb.E_TitleI18N) {
+		hasTitle = true
+	}
+	g.applyLocalized(schema, "description", msgOpts, jsonschemapb.E_MessageDescriptionI18N)
+
+	if g.openapiv2 {
+		if err := applyOpenAPIv2Message(md, schema, hasTitle); err != nil {
+			return nil, err
+		}
+	}
+
+	rules, err := protovalidateMessageRules(md)
+	if err != nil {
+		return nil, err
+	}
+	if rules != nil {
+		appendValidationNotes(schema, celNotes(rules))
+	}
+	applyDeprecation(schema, msgOpts.GetDeprecated(), msgOpts, jsonschemapb.E_MessageDeprecationNote)
+
+	g.applyObjectConstraints(schema, msgOpts)
+
+	if err := g.applyConditionals(schema, md, msgOpts); err != nil {
+		return nil, err
+	}
+
+	examples, err := messageExamples(md, msgOpts)
+	if err != nil {
+		return nil, err
+	}
+	if len(examples) > 0 {
+		schema["examples"] = examples
+	}
+
+	if err := applyVendorExtensions(schema, md.FullName(), msgOpts, jsonschemapb.E_MessageVendorExtension); err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// messageExamples parses the message_example options of md. Each example must
+// be a JSON object, since it describes a complete message payload.
+func messageExamples(md protoreflect.MessageDescriptor, msgOpts *descriptorpb.MessageOptions) ([]interface{}, error) {
+	if !proto.HasExtension(msgOpts, jsonschemapb.E_MessageExample) {
+		return nil, nil
+	}
+
+	raw := proto.GetExtension(msgOpts, jsonschemapb.E_MessageExample).([]string)
+	examples := make([]interface{}, 0, len(raw))
+	for i, example := range raw {
+		var value map[string]interface{}
+		if err := json.Unmarshal([]byte(example), &value); err != nil {
+			return nil, fmt.Errorf("%s: message_example[%d] is not a JSON object: %w", md.FullName(), i, err)
+		}
+		examples = append(examples, value)
+	}
+	return examples, nil
+}
+
+// applyObjectConstraints sets additionalProperties, minProperties and
+// maxProperties from the message options. additionalProperties falls back to
+// false for strict objects and is otherwise left unset.
+func (g *Generator) applyObjectConstraints(schema Schema, msgOpts *descriptorpb.MessageOptions) {
+	if proto.HasExtension(msgOpts, jsonschemapb.E_AdditionalProperties) {
+		schema["additionalProperties"] = proto.GetExtension(msgOpts, jsonschemapb.E_AdditionalProperties).(bool)
+	} else if g.strictObjects {
+		schema["additionalProperties"] = false
+	}
+	applyExt[int32](schema, msgOpts, "minProperties", jsonschemapb.E_MinProperties)
+	applyExt[int32](schema, msgOpts, "maxProperties", jsonschemapb.E_MaxProperties)
 }
 
 // getSchemaTitle returns the schema title from options or message name
@@ -153,19 +317,53 @@ func (g *Generator) getSchemaTitle(md protoreflect.MessageDescriptor, msgOpts *d
 	return string(md.Name())
 }
 
-// processFields processes all fields and returns properties and required fields
-func (g *Generator) processFields(fields protoreflect.FieldDescriptors) (map[string]interface{}, []string) {
-	properties := make(map[string]interface{})
-	required := []string{}
+// fieldSet holds the properties generated for the fields of a message
+type fieldSet struct {
+	properties map[string]interface{}
+	required   []string
+	// order lists property names in field order
+	order []string
+	// alternatives holds the mutual exclusion constraints of fields accepted
+	// under several names
+	alternatives []interface{}
+}
+
+// processFields processes all fields and returns their properties, required
+// names and naming constraints
+func (g *Generator) processFields(fields protoreflect.FieldDescriptors, partial *partialScope) (*fieldSet, error) {
+	set := &fieldSet{properties: make(map[string]interface{}), required: []string{}}
 
-	g.forEachVisibleField(fields, func(name string, fieldSchema Schema, isRequired bool) {
-		properties[name] = fieldSchema
-		if isRequired {
-			required = append(required, name)
+	err := g.forEachVisibleField(fields, partial, func(names []string, fieldSchema Schema, isRequired bool) {
+		for i, name := range names {
+			if i > 0 {
+				set.properties[name] = deepCopy(fieldSchema)
+			} else {
+				set.properties[name] = fieldSchema
+			}
+			set.order = append(set.order, name)
+		}
+		switch {
+		case len(names) > 1:
+			set.alternatives = append(set.alternatives, alternativeNames(names, isRequired))
+		case isRequired:
+			set.required = append(set.required, names[0])
 		}
 	})
 
-	return properties, required
+	return set, err
+}
+
+// apply writes the properties, required list and naming constraints of set
+// into schema, appending the constraints to any allOf already there
+func (set *fieldSet) apply(schema Schema) {
+	schema["properties"] = set.properties
+	if len(set.required) > 0 {
+		schema["required"] = set.required
+	}
+	if len(set.alternatives) > 0 {
+		allOf, _ := schema["allOf"].([]interface{})
+		schema["allOf"] = append(allOf, set.alternatives...)
+	}
 }
 
 // isFieldHidden checks if a field should be hidden
@@ -176,27 +374,23 @@ func (g *Generator) isFieldHidden(fieldOpts *descriptorpb.FieldOptions) bool {
 	return false
 }
 
-// getFieldName returns the JSON name for a field
+// getFieldName returns the primary property name of a field under the
+// naming strategy
 func (g *Generator) getFieldName(field protoreflect.FieldDescriptor, fieldOpts *descriptorpb.FieldOptions) string {
-	if proto.HasExtension(fieldOpts, jsonschemapb.E_JsonName) {
-		return proto.GetExtension(fieldOpts, jsonschemapb.E_JsonName).(string)
-	}
-	if field.JSONName() != "" {
-		return field.JSONName()
-	}
-	return string(field.Name())
+	return g.fieldNames(field, fieldOpts)[0]
 }
 
-// isFieldRequired checks if a field is required
-func (g *Generator) isFieldRequired(fieldOpts *descriptorpb.FieldOptions) bool {
+// isFieldRequired checks if a field is required, by the required option or
+// else by its validation rules or, when enabled, openapiv2 annotations
+func (g *Generator) isFieldRequired(field protoreflect.FieldDescriptor, fieldOpts *descriptorpb.FieldOptions) bool {
 	if proto.HasExtension(fieldOpts, jsonschemapb.E_Required) {
 		return proto.GetExtension(fieldOpts, jsonschemapb.E_Required).(bool)
 	}
-	return false
+	return g.isValidationRequired(field) || (g.openapiv2 && isOpenAPIv2Required(field))
 }
 
 // generateFieldSchema generates JSON Schema for a field
-func (g *Generator) generateFieldSchema(field protoreflect.FieldDescriptor, opts *descriptorpb.FieldOptions) Schema {
+func (g *Generator) generateFieldSchema(field protoreflect.FieldDescriptor, opts *descriptorpb.FieldOptions) (Schema, error) {
 	schema := Schema{}
 
 	// Set type based on protobuf type
@@ -218,15 +412,19 @@ func (g *Generator) generateFieldSchema(field protoreflect.FieldDescriptor, opts
 	case protoreflect.EnumKind:
 		schema["type"] = "string"
 		// Add enum values
-		enumValues := []string{}
 		enumDesc := field.Enum()
-		for i := 0; i < enumDesc.Values().Len(); i++ {
-			enumValues = append(enumValues, string(enumDesc.Values().Get(i).Name()))
+		schema["enum"] = g.enumValueNames(enumDesc)
+		if err := applyVendorExtensions(schema, enumDesc.FullName(), enumDesc.Options(), jsonschemapb.E_EnumVendorExtension); err != nil {
+			return nil, err
 		}
-		schema["enum"] = enumValues
 	case protoreflect.MessageKind:
-		// Special handling for google.protobuf.Timestamp
-		if field.Message().FullName() == "google.protobuf.Timestamp" {
+		// Registered type mappers take precedence over the built-in mappings
+		if mapper, ok := g.typeMapper(field); ok {
+			if schema = mapper(field); schema == nil {
+				schema = Schema{}
+			}
+		} else if field.Message().FullName() == "google.protobuf.Timestamp" {
+			// Special handling for google.protobuf.Timestamp
 			schema = Schema{
 				"oneOf": []interface{}{
 					map[string]interface{}{
@@ -253,6 +451,8 @@ func (g *Generator) generateFieldSchema(field protoreflect.FieldDescriptor, opts
 					},
 				},
 			}
+		} else if md, ok := g.refMessage(field); ok {
+			schema["$ref"] = g.refPrefix + string(md.FullName())
 		} else {
 			schema["type"] = "object"
 		}
@@ -266,8 +466,23 @@ func (g *Generator) generateFieldSchema(field protoreflect.FieldDescriptor, opts
 		}
 	}
 
+	// Fallback annotations go first, then validation rules, so that explicit
+	// options below win on conflicts
+	if g.openapiv2 {
+		if err := applyOpenAPIv2Field(field, schema); err != nil {
+			return nil, err
+		}
+	}
+	validationNotes, err := g.applyValidationRules(field, schema)
+	if err != nil {
+		return nil, err
+	}
+
 	// Apply custom options
+	applyExt[string](schema, opts, "title", jsonschemapb.E_FieldTitle)
 	applyExt[string](schema, opts, "description", jsonschemapb.E_Description)
+	g.applyLocalized(schema, "title", opts, jsonschemapb.E_FieldTitleI18N)
+	g.applyLocalized(schema, "description", opts, jsonschemapb.E_DescriptionI18N)
 	applyExt[string](schema, opts, "example", jsonschemapb.E_Example)
 	applyExt[string](schema, opts, "format", jsonschemapb.E_Format)
 	applyExt[int32](schema, opts, "minLength", jsonschemapb.E_MinLength)
@@ -275,23 +490,49 @@ func (g *Generator) generateFieldSchema(field protoreflect.FieldDescriptor, opts
 	applyExt[float64](schema, opts, "minimum", jsonschemapb.E_Minimum)
 	applyExt[float64](schema, opts, "maximum", jsonschemapb.E_Maximum)
 	applyExt[string](schema, opts, "pattern", jsonschemapb.E_Pattern)
+	if g.openapiv2 && proto.HasExtension(opts, jsonschemapb.E_Example) {
+		delete(schema, "examples")
+	}
+	if proto.HasExtension(opts, jsonschemapb.E_Minimum) {
+		delete(schema, "exclusiveMinimum")
+	}
+	if proto.HasExtension(opts, jsonschemapb.E_Maximum) {
+		delete(schema, "exclusiveMaximum")
+	}
+	appendValidationNotes(schema, validationNotes)
+	applyDeprecation(schema, opts.GetDeprecated(), opts, jsonschemapb.E_DeprecationNote)
+	applyExt[string](schema, opts, "x-since", jsonschemapb.E_Since)
+	applyExt[string](schema, opts, "x-removed-in", jsonschemapb.E_RemovedIn)
+
+	// default is special: its string payload is parsed as JSON and checked
+	// against the field type.
+	defaultValue, ok, err := g.fieldDefault(field, opts)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		schema["default"] = defaultValue
+	}
 
-	// default is special: its string payload is parsed as JSON and skipped on error.
-	if proto.HasExtension(opts, jsonschemapb.E_Default) {
-		defaultStr := proto.GetExtension(opts, jsonschemapb.E_Default).(string)
-		var defaultValue interface{}
-		if err := json.Unmarshal([]byte(defaultStr), &defaultValue); err == nil {
-			schema["default"] = defaultValue
-		}
+	if err := applyVendorExtensions(schema, field.FullName(), opts, jsonschemapb.E_VendorExtension); err != nil {
+		return nil, err
+	}
+
+	schema, err = applySchemaJSON(schema, opts, jsonschemapb.E_SchemaJson, jsonschemapb.E_SchemaJsonReplace)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid schema_json: %w", field.FullName(), err)
+	}
+	if g.nullable && field.HasOptionalKeyword() {
+		schema = nullableSchema(schema)
 	}
 
-	return schema
+	return schema, nil
 }
 
-// applyExt copies a present field-option extension of type T into schema[key],
-// preserving the extension's exact Go type (string/int32/float64) so downstream
-// type assertions and generated literals stay byte-stable.
-func applyExt[T any](schema Schema, opts *descriptorpb.FieldOptions, key string, ext protoreflect.ExtensionType) {
+// applyExt copies a present field- or message-option extension of type T into
+// schema[key], preserving the extension's exact Go type (string/int32/float64)
+// so downstream type assertions and generated literals stay byte-stable.
+func applyExt[T any](schema Schema, opts proto.Message, key string, ext protoreflect.ExtensionType) {
 	if proto.HasExtension(opts, ext) {
 		schema[key] = proto.GetExtension(opts, ext).(T)
 	}
@@ -317,11 +558,146 @@ func (s Schema) ToJSONBytes() ([]byte, error) {
 
 // OrderedSchema represents a JSON Schema with ordered fields
 type OrderedSchema struct {
-	Type        string
-	Title       string
-	Description string
-	Properties  []OrderedProperty
-	Required    []string
+	// SchemaURI is the $schema meta-schema URI, written first
+	SchemaURI            string
+	Type                 string
+	Title                string
+	Description          string
+	Properties           []OrderedProperty
+	Required             []string
+	AdditionalProperties *bool
+	MinProperties        *int32
+	MaxProperties        *int32
+	Examples             []interface{}
+	// Keywords holds any further schema keywords (e.g. dependentRequired,
+	// if/then/else, allOf), written last in sorted key order
+	Keywords map[string]interface{}
+}
+
+// newOrderedSchema converts a generated schema into an OrderedSchema, listing
+// properties in the given field order. Properties missing from order (e.g.
+// added by message_schema_json) follow in sorted order, and keywords without a
+// dedicated field, or whose value does not fit it, go to Keywords.
+func newOrderedSchema(schema Schema, order []string) *OrderedSchema {
+	orderedSchema := &OrderedSchema{
+		Properties: []OrderedProperty{},
+		Required:   []string{},
+	}
+	keyword := func(key string, value interface{}) {
+		if orderedSchema.Keywords == nil {
+			orderedSchema.Keywords = map[string]interface{}{}
+		}
+		orderedSchema.Keywords[key] = value
+	}
+
+	for key, value := range schema {
+		var ok bool
+		switch key {
+		case "$schema":
+			orderedSchema.SchemaURI, ok = value.(string)
+		case "type":
+			orderedSchema.Type, ok = value.(string)
+		case "title":
+			orderedSchema.Title, ok = value.(string)
+		case "description":
+			orderedSchema.Description, ok = value.(string)
+		case "properties":
+			var properties map[string]interface{}
+			if properties, ok = asObject(value); ok {
+				orderedSchema.Properties, ok = orderedProperties(properties, order)
+			}
+		case "required":
+			orderedSchema.Required, ok = stringList(value)
+		case "additionalProperties":
+			var v bool
+			if v, ok = value.(bool); ok {
+				orderedSchema.AdditionalProperties = &v
+			}
+		case "minProperties":
+			var v int32
+			if v, ok = int32Value(value); ok {
+				orderedSchema.MinProperties = &v
+			}
+		case "maxProperties":
+			var v int32
+			if v, ok = int32Value(value); ok {
+				orderedSchema.MaxProperties = &v
+			}
+		case "examples":
+			orderedSchema.Examples, ok = value.([]interface{})
+		}
+		if !ok {
+			keyword(key, value)
+		}
+	}
+	return orderedSchema
+}
+
+// orderedProperties lists properties in field order, followed by any
+// remaining ones in sorted order. It reports false if a property is not an
+// object schema.
+func orderedProperties(properties map[string]interface{}, order []string) ([]OrderedProperty, bool) {
+	result := make([]OrderedProperty, 0, len(properties))
+	seen := make(map[string]bool, len(order))
+	appendProperty := func(name string) bool {
+		propSchema, ok := asObject(properties[name])
+		result = append(result, OrderedProperty{Name: name, Schema: propSchema})
+		seen[name] = true
+		return ok
+	}
+
+	for _, name := range order {
+		if _, ok := properties[name]; ok && !seen[name] {
+			if !appendProperty(name) {
+				return nil, false
+			}
+		}
+	}
+	rest := make([]string, 0, len(properties)-len(seen))
+	for name := range properties {
+		if !seen[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	for _, name := range rest {
+		if !appendProperty(name) {
+			return nil, false
+		}
+	}
+	return result, true
+}
+
+// stringList converts a []string or a decoded JSON array of strings
+func stringList(value interface{}) ([]string, bool) {
+	switch v := value.(type) {
+	case []string:
+		return v, true
+	case []interface{}:
+		list := make([]string, len(v))
+		for i, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			list[i] = s
+		}
+		return list, true
+	}
+	return nil, false
+}
+
+// int32Value converts an int32 option value or a decoded integral JSON number
+func int32Value(value interface{}) (int32, bool) {
+	switch v := value.(type) {
+	case int32:
+		return v, true
+	case float64:
+		if v == float64(int32(v)) {
+			return int32(v), true
+		}
+	}
+	return 0, false
 }
 
 // OrderedProperty represents an ordered property
@@ -337,8 +713,20 @@ func (os *OrderedSchema) MarshalJSON() ([]byte, error) {
 
 	first := true
 
+	// $schema
+	if os.SchemaURI != "" {
+		buf.WriteString(`"$schema":`)
+		if err := writeJSONString(&buf, os.SchemaURI); err != nil {
+			return nil, err
+		}
+		first = false
+	}
+
 	// type
 	if os.Type != "" {
+		if !first {
+			buf.WriteString(",")
+		}
 		buf.WriteString(`"type":`)
 		if err := writeJSONString(&buf, os.Type); err != nil {
 			return nil, err
@@ -405,6 +793,70 @@ func (os *OrderedSchema) MarshalJSON() ([]byte, error) {
 		}
 		buf.WriteString(`"required":`)
 		buf.Write(reqJSON)
+		first = false
+	}
+
+	// additionalProperties
+	if os.AdditionalProperties != nil {
+		if !first {
+			buf.WriteString(",")
+		}
+		fmt.Fprintf(&buf, `"additionalProperties":%t`, *os.AdditionalProperties)
+		first = false
+	}
+
+	// minProperties
+	if os.MinProperties != nil {
+		if !first {
+			buf.WriteString(",")
+		}
+		fmt.Fprintf(&buf, `"minProperties":%d`, *os.MinProperties)
+		first = false
+	}
+
+	// maxProperties
+	if os.MaxProperties != nil {
+		if !first {
+			buf.WriteString(",")
+		}
+		fmt.Fprintf(&buf, `"maxProperties":%d`, *os.MaxProperties)
+		first = false
+	}
+
+	// examples
+	if len(os.Examples) > 0 {
+		if !first {
+			buf.WriteString(",")
+		}
+		examplesJSON, err := json.Marshal(os.Examples)
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString(`"examples":`)
+		buf.Write(examplesJSON)
+		first = false
+	}
+
+	// remaining keywords, sorted for deterministic output
+	keys := make([]string, 0, len(os.Keywords))
+	for key := range os.Keywords {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !first {
+			buf.WriteString(",")
+		}
+		if err := writeJSONString(&buf, key); err != nil {
+			return nil, err
+		}
+		valueJSON, err := json.Marshal(os.Keywords[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString(":")
+		buf.Write(valueJSON)
+		first = false
 	}
 
 	buf.WriteString("}")
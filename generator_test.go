@@ -297,3 +297,133 @@ func TestShouldGenerateSchema_ExplicitFalse(t *testing.T) {
 		t.Error("generate_schema=false must disable generation")
 	}
 }
+
+func TestGenerateSchema_ObjectConstraints(t *testing.T) {
+	fd := testFile(t,
+		testMessage("Strict", msgOpts(
+			ext(jsonschemapb.E_AdditionalProperties, false),
+			ext(jsonschemapb.E_MinProperties, int32(1)),
+			ext(jsonschemapb.E_MaxProperties, int32(2)),
+		),
+			testField("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+		),
+		testMessage("Open", msgOpts(ext(jsonschemapb.E_AdditionalProperties, true)),
+			testField("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+		),
+		testMessage("Plain", nil,
+			testField("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+		),
+	)
+
+	g := NewGenerator()
+	strict, err := g.GenerateSchema(fd.Messages().ByName("Strict"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	if strict["additionalProperties"] != false {
+		t.Errorf("expected additionalProperties false, got %v", strict["additionalProperties"])
+	}
+	if strict["minProperties"] != int32(1) || strict["maxProperties"] != int32(2) {
+		t.Errorf("expected min/maxProperties 1/2, got %v/%v", strict["minProperties"], strict["maxProperties"])
+	}
+
+	plain, _ := g.GenerateSchema(fd.Messages().ByName("Plain"))
+	if _, ok := plain["additionalProperties"]; ok {
+		t.Error("additionalProperties must be unset without option or strict default")
+	}
+
+	g.SetStrictObjects(true)
+	if !g.IsStrictObjects() {
+		t.Fatal("SetStrictObjects(true) did not take effect")
+	}
+	plain, _ = g.GenerateSchema(fd.Messages().ByName("Plain"))
+	if plain["additionalProperties"] != false {
+		t.Errorf("strict default must set additionalProperties false, got %v", plain["additionalProperties"])
+	}
+	open, _ := g.GenerateSchema(fd.Messages().ByName("Open"))
+	if open["additionalProperties"] != true {
+		t.Errorf("explicit option must win over strict default, got %v", open["additionalProperties"])
+	}
+}
+
+func TestGenerateOrderedSchema_ObjectConstraints(t *testing.T) {
+	fd := testFile(t,
+		testMessage("Strict", msgOpts(
+			ext(jsonschemapb.E_AdditionalProperties, false),
+			ext(jsonschemapb.E_MinProperties, int32(1)),
+			ext(jsonschemapb.E_MaxProperties, int32(2)),
+		),
+			testField("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+		),
+	)
+
+	ordered, err := NewGeneratorWithOptions(true).GenerateOrderedSchema(fd.Messages().ByName("Strict"))
+	if err != nil {
+		t.Fatalf("GenerateOrderedSchema failed: %v", err)
+	}
+	data, err := json.Marshal(ordered)
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+	want := `{"type":"object","title":"Strict","properties":{"name":{"type":"string"}},"additionalProperties":false,"minProperties":1,"maxProperties":2}`
+	if string(data) != want {
+		t.Errorf("unexpected ordered schema\n got: %s\nwant: %s", data, want)
+	}
+}
+
+func TestGenerateSchema_MessageExamples(t *testing.T) {
+	fd := testFile(t,
+		testMessage("Search", msgOpts(ext(jsonschemapb.E_MessageExample, []string{
+			`{"query": "golang", "limit": 10}`,
+			`{"query": "protobuf"}`,
+		})),
+			testField("query", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+			testField("limit", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, nil),
+		),
+	)
+	md := fd.Messages().ByName("Search")
+
+	schema, err := NewGenerator().GenerateSchema(md)
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	examples, ok := schema["examples"].([]interface{})
+	if !ok || len(examples) != 2 {
+		t.Fatalf("expected 2 examples, got %v", schema["examples"])
+	}
+	first := examples[0].(map[string]interface{})
+	if first["query"] != "golang" || first["limit"] != float64(10) {
+		t.Errorf("unexpected first example %v", first)
+	}
+
+	ordered, err := NewGeneratorWithOptions(true).GenerateOrderedSchema(md)
+	if err != nil {
+		t.Fatalf("GenerateOrderedSchema failed: %v", err)
+	}
+	data, err := json.Marshal(ordered)
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+	want := `{"type":"object","title":"Search","properties":{"query":{"type":"string"},"limit":{"type":"integer"}},"examples":[{"limit":10,"query":"golang"},{"query":"protobuf"}]}`
+	if string(data) != want {
+		t.Errorf("unexpected ordered schema\n got: %s\nwant: %s", data, want)
+	}
+}
+
+func TestGenerateSchema_InvalidMessageExample(t *testing.T) {
+	for _, example := range []string{`{"query": `, `["not", "an", "object"]`} {
+		fd := testFile(t,
+			testMessage("Search", msgOpts(ext(jsonschemapb.E_MessageExample, []string{example})),
+				testField("query", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+			),
+		)
+		md := fd.Messages().ByName("Search")
+
+		if _, err := NewGenerator().GenerateSchema(md); err == nil {
+			t.Errorf("expected error for message_example %s", example)
+		}
+		if _, err := NewGeneratorWithOptions(true).GenerateOrderedSchema(md); err == nil {
+			t.Errorf("expected ordered error for message_example %s", example)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	// Register the well-known types referenced by test descriptors.
+	_ "google.golang.org/protobuf/types/known/fieldmaskpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// testFile builds a proto3 file descriptor in package "test" from the given
+// messages, resolving its well-known type imports against the global registry.
+func testFile(t *testing.T, messages ...*descriptorpb.DescriptorProto) protoreflect.FileDescriptor {
+	t.Helper()
+	return testFileWithEnums(t, nil, messages...)
+}
+
+// testFileWithEnums is testFile with additional top-level enums
+func testFileWithEnums(t *testing.T, enums []*descriptorpb.EnumDescriptorProto, messages ...*descriptorpb.DescriptorProto) protoreflect.FileDescriptor {
+	t.Helper()
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String(t.Name() + ".proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		Dependency: []string{
+			"google/protobuf/field_mask.proto",
+			"google/protobuf/timestamp.proto",
+		},
+		MessageType: messages,
+		EnumType:    enums,
+	}
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatalf("failed to build test descriptor: %v", err)
+	}
+	return fd
+}
+
+// testMessage builds a message descriptor proto with the given options and fields
+func testMessage(name string, opts *descriptorpb.MessageOptions, fields ...*descriptorpb.FieldDescriptorProto) *descriptorpb.DescriptorProto {
+	return &descriptorpb.DescriptorProto{Name: proto.String(name), Options: opts, Field: fields}
+}
+
+// testField builds a singular scalar field descriptor proto
+func testField(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, opts *descriptorpb.FieldOptions) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:    proto.String(name),
+		Number:  proto.Int32(number),
+		Label:   descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:    typ.Enum(),
+		Options: opts,
+	}
+}
+
+// testMessageField builds a singular message-typed field descriptor proto;
+// typeName is fully qualified with a leading dot (e.g. ".test.Book").
+func testMessageField(name string, number int32, typeName string, opts *descriptorpb.FieldOptions) *descriptorpb.FieldDescriptorProto {
+	field := testField(name, number, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, opts)
+	field.TypeName = proto.String(typeName)
+	return field
+}
+
+// repeated marks a field descriptor proto as repeated
+func repeated(field *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+	field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	return field
+}
+
+// fieldOpts returns FieldOptions with the given extensions set
+func fieldOpts(exts ...extValue) *descriptorpb.FieldOptions {
+	opts := &descriptorpb.FieldOptions{}
+	for _, e := range exts {
+		proto.SetExtension(opts, e.ext, e.value)
+	}
+	return opts
+}
+
+// msgOpts returns MessageOptions with the given extensions set
+func msgOpts(exts ...extValue) *descriptorpb.MessageOptions {
+	opts := &descriptorpb.MessageOptions{}
+	for _, e := range exts {
+		proto.SetExtension(opts, e.ext, e.value)
+	}
+	return opts
+}
+
+// methodOpts returns MethodOptions with the given extensions set
+func methodOpts(exts ...extValue) *descriptorpb.MethodOptions {
+	opts := &descriptorpb.MethodOptions{}
+	for _, e := range exts {
+		proto.SetExtension(opts, e.ext, e.value)
+	}
+	return opts
+}
+
+// extValue pairs an extension type with the value to set on an options message
+type extValue struct {
+	ext   protoreflect.ExtensionType
+	value interface{}
+}
+
+// ext is shorthand for constructing an extValue
+func ext(xt protoreflect.ExtensionType, value interface{}) extValue {
+	return extValue{ext: xt, value: value}
+}
@@ -0,0 +1,61 @@
+package jsonschema
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FieldHook post-processes the schema generated for a field, after all field
+// options have been applied. It may mutate schema and return it, or return a
+// different schema to replace it; returning nil omits the field from the
+// message schema.
+type FieldHook func(field protoreflect.FieldDescriptor, schema Schema) (Schema, error)
+
+// MessageHook post-processes the schema generated for a message, after all
+// message options have been applied. It may mutate schema and return it, or
+// return a different schema to replace it; returning nil skips generation for
+// the message, as if generate_schema were false.
+type MessageHook func(md protoreflect.MessageDescriptor, schema Schema) (Schema, error)
+
+// AddFieldHook registers a hook invoked for every visible field schema,
+// including fields of nested messages expanded by a partial schema. Hooks run
+// in registration order, each receiving the previous hook's result.
+func (g *Generator) AddFieldHook(hook FieldHook) {
+	g.fieldHooks = append(g.fieldHooks, hook)
+}
+
+// AddMessageHook registers a hook invoked for every generated message schema
+// by both GenerateSchema and GenerateOrderedSchema. Hooks run in registration
+// order, each receiving the previous hook's result.
+func (g *Generator) AddMessageHook(hook MessageHook) {
+	g.messageHooks = append(g.messageHooks, hook)
+}
+
+// runFieldHooks applies the registered field hooks to schema
+func (g *Generator) runFieldHooks(field protoreflect.FieldDescriptor, schema Schema) (Schema, error) {
+	for _, hook := range g.fieldHooks {
+		var err error
+		if schema, err = hook(field, schema); err != nil {
+			return nil, fmt.Errorf("%s: field hook: %w", field.FullName(), err)
+		}
+		if schema == nil {
+			return nil, nil
+		}
+	}
+	return schema, nil
+}
+
+// runMessageHooks applies the registered message hooks to schema
+func (g *Generator) runMessageHooks(md protoreflect.MessageDescriptor, schema Schema) (Schema, error) {
+	for _, hook := range g.messageHooks {
+		var err error
+		if schema, err = hook(md, schema); err != nil {
+			return nil, fmt.Errorf("%s: message hook: %w", md.FullName(), err)
+		}
+		if schema == nil {
+			return nil, nil
+		}
+	}
+	return schema, nil
+}
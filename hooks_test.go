@@ -0,0 +1,126 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+func hookFile(t *testing.T) protoreflect.FileDescriptor {
+	t.Helper()
+	return testFile(t,
+		testMessage("User", nil,
+			testField("email", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(
+				ext(jsonschemapb.E_Required, true),
+				ext(jsonschemapb.E_Example, "alice@example.com"),
+			)),
+			testField("internal_id", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+			testField("name", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(ext(jsonschemapb.E_Example, "Alice"))),
+		),
+	)
+}
+
+func TestHooks_MutateReplaceAndOmit(t *testing.T) {
+	fd := hookFile(t)
+	g := NewGenerator()
+	g.AddFieldHook(func(field protoreflect.FieldDescriptor, schema Schema) (Schema, error) {
+		delete(schema, "example")
+		return schema, nil
+	})
+	g.AddFieldHook(func(field protoreflect.FieldDescriptor, schema Schema) (Schema, error) {
+		if field.Name() == "internal_id" {
+			return nil, nil
+		}
+		return schema, nil
+	})
+	g.AddMessageHook(func(md protoreflect.MessageDescriptor, schema Schema) (Schema, error) {
+		schema["$comment"] = "generated from " + string(md.FullName())
+		return schema, nil
+	})
+
+	schema, err := g.GenerateSchema(fd.Messages().ByName("User"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	m := mustSchemaMap(t, schema)
+	if m["$comment"] != "generated from test.User" {
+		t.Errorf("expected message hook $comment, got %v", m["$comment"])
+	}
+	props := m["properties"].(map[string]interface{})
+	if _, ok := props["internalId"]; ok {
+		t.Error("field omitted by hook must not be emitted")
+	}
+	for name, prop := range props {
+		if _, ok := prop.(map[string]interface{})["example"]; ok {
+			t.Errorf("expected example stripped from %s", name)
+		}
+	}
+}
+
+func TestHooks_OrderedSchema(t *testing.T) {
+	fd := hookFile(t)
+	g := NewGeneratorWithOptions(true)
+	g.AddFieldHook(func(field protoreflect.FieldDescriptor, schema Schema) (Schema, error) {
+		if field.Name() == "internal_id" {
+			return nil, nil
+		}
+		return Schema{"type": schema["type"]}, nil
+	})
+	g.AddMessageHook(func(md protoreflect.MessageDescriptor, schema Schema) (Schema, error) {
+		schema["$comment"] = "internal"
+		return schema, nil
+	})
+
+	ordered, err := g.GenerateOrderedSchema(fd.Messages().ByName("User"))
+	if err != nil {
+		t.Fatalf("GenerateOrderedSchema failed: %v", err)
+	}
+	data, err := json.Marshal(ordered)
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+	want := `{"type":"object","title":"User","properties":{"email":{"type":"string"},"name":{"type":"string"}},"required":["email"],"$comment":"internal"}`
+	if string(data) != want {
+		t.Errorf("unexpected ordered schema\n got: %s\nwant: %s", data, want)
+	}
+}
+
+func TestHooks_MessageHookSkipsGeneration(t *testing.T) {
+	fd := hookFile(t)
+	g := NewGenerator()
+	g.AddMessageHook(func(protoreflect.MessageDescriptor, Schema) (Schema, error) { return nil, nil })
+
+	schema, err := g.GenerateSchema(fd.Messages().ByName("User"))
+	if err != nil || schema != nil {
+		t.Errorf("expected nil schema, got %v, %v", schema, err)
+	}
+	ordered, err := g.GenerateOrderedSchema(fd.Messages().ByName("User"))
+	if err != nil || ordered != nil {
+		t.Errorf("expected nil ordered schema, got %v, %v", ordered, err)
+	}
+}
+
+func TestHooks_Errors(t *testing.T) {
+	fd := hookFile(t)
+	errBoom := errors.New("boom")
+
+	g := NewGenerator()
+	g.AddFieldHook(func(protoreflect.FieldDescriptor, Schema) (Schema, error) { return nil, errBoom })
+	_, err := g.GenerateSchema(fd.Messages().ByName("User"))
+	if !errors.Is(err, errBoom) || !strings.Contains(err.Error(), "test.User.email: field hook") {
+		t.Errorf("expected wrapped field hook error, got %v", err)
+	}
+
+	g = NewGenerator()
+	g.AddMessageHook(func(protoreflect.MessageDescriptor, Schema) (Schema, error) { return nil, errBoom })
+	_, err = g.GenerateSchema(fd.Messages().ByName("User"))
+	if !errors.Is(err, errBoom) || !strings.Contains(err.Error(), "test.User: message hook") {
+		t.Errorf("expected wrapped message hook error, got %v", err)
+	}
+}
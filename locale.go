@@ -0,0 +1,63 @@
+package jsonschema
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+// SetLocale selects the language of localized titles and descriptions. A
+// locale such as "zh-Hant-TW" falls back to "zh-Hant" and then "zh" before
+// the unlocalized options are used; an empty locale renders only the
+// unlocalized options.
+func (g *Generator) SetLocale(locale string) {
+	g.locale = locale
+}
+
+// Locale returns the locale selected by SetLocale
+func (g *Generator) Locale() string {
+	return g.locale
+}
+
+// applyLocalized sets schema[key] to the text of the *_i18n option ext that
+// best matches the generator's locale, and reports whether one matched
+func (g *Generator) applyLocalized(schema Schema, key string, opts proto.Message, ext protoreflect.ExtensionType) bool {
+	if g.locale == "" || !proto.HasExtension(opts, ext) {
+		return false
+	}
+	texts := proto.GetExtension(opts, ext).([]*jsonschemapb.LocalizedText)
+	for _, locale := range localeFallbacks(g.locale) {
+		for _, text := range texts {
+			if normalizeLocale(text.GetLocale()) == locale {
+				schema[key] = text.GetText()
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// localeFallbacks lists locale and its less specific parents, most specific
+// first: "zh-Hant-TW" yields "zh-hant-tw", "zh-hant", "zh"
+func localeFallbacks(locale string) []string {
+	locale = normalizeLocale(locale)
+	var chain []string
+	for locale != "" {
+		chain = append(chain, locale)
+		i := strings.LastIndexByte(locale, '-')
+		if i < 0 {
+			break
+		}
+		locale = locale[:i]
+	}
+	return chain
+}
+
+// normalizeLocale makes locale tags comparable regardless of case and of
+// "_" versus "-" as the subtag separator
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
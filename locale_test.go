@@ -0,0 +1,78 @@
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+func localized(pairs ...string) []*jsonschemapb.LocalizedText {
+	var texts []*jsonschemapb.LocalizedText
+	for i := 0; i+1 < len(pairs); i += 2 {
+		texts = append(texts, &jsonschemapb.LocalizedText{Locale: pairs[i], Text: pairs[i+1]})
+	}
+	return texts
+}
+
+func TestLocaleFallbacks(t *testing.T) {
+	got := localeFallbacks("zh_Hant-TW")
+	want := []string{"zh-hant-tw", "zh-hant", "zh"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("localeFallbacks = %v, want %v", got, want)
+	}
+	if got := localeFallbacks(""); len(got) != 0 {
+		t.Errorf("expected no fallbacks for empty locale, got %v", got)
+	}
+}
+
+func TestLocale_TitlesAndDescriptions(t *testing.T) {
+	fd := testFile(t,
+		testMessage("Greeting", msgOpts(
+			ext(jsonschemapb.E_Title, "Greeting"),
+			ext(jsonschemapb.E_MessageDescription, "A greeting"),
+			ext(jsonschemapb.E_TitleI18N, localized("zh", "问候")),
+			ext(jsonschemapb.E_MessageDescriptionI18N, localized("zh-CN", "一条问候")),
+		),
+			testField("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(
+				ext(jsonschemapb.E_FieldTitle, "Name"),
+				ext(jsonschemapb.E_Description, "Who to greet"),
+				ext(jsonschemapb.E_FieldTitleI18N, localized("zh", "名字")),
+				ext(jsonschemapb.E_DescriptionI18N, localized("en", "Who to greet, in English", "zh_cn", "问候对象")),
+			)),
+		),
+	)
+	md := fd.Messages().ByName("Greeting")
+
+	tests := []struct {
+		locale                       string
+		title, description           string
+		fieldTitle, fieldDescription string
+	}{
+		{"", "Greeting", "A greeting", "Name", "Who to greet"},
+		{"zh-CN", "问候", "一条问候", "名字", "问候对象"},
+		{"zh-TW", "问候", "A greeting", "名字", "Who to greet"},
+		{"en-US", "Greeting", "A greeting", "Name", "Who to greet, in English"},
+		{"fr", "Greeting", "A greeting", "Name", "Who to greet"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			g := NewGenerator()
+			g.SetLocale(tt.locale)
+			schema, err := g.GenerateSchema(md)
+			if err != nil {
+				t.Fatalf("GenerateSchema failed: %v", err)
+			}
+			m := mustSchemaMap(t, schema)
+			if m["title"] != tt.title || m["description"] != tt.description {
+				t.Errorf("message title/description = %q/%q, want %q/%q", m["title"], m["description"], tt.title, tt.description)
+			}
+			name := m["properties"].(map[string]interface{})["name"].(map[string]interface{})
+			if name["title"] != tt.fieldTitle || name["description"] != tt.fieldDescription {
+				t.Errorf("field title/description = %q/%q, want %q/%q", name["title"], name["description"], tt.fieldTitle, tt.fieldDescription)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
 	reflect "reflect"
+	sync "sync"
 	unsafe "unsafe"
 )
 
@@ -21,6 +22,236 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// 字段依赖：设置 field 时，requires 中的字段也必须设置（均为 proto 字段名）
+type DependentRequired struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Requires      []string               `protobuf:"bytes,2,rep,name=requires,proto3" json:"requires,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DependentRequired) Reset() {
+	*x = DependentRequired{}
+	mi := &file_mcp_jsonschema_jsonschema_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DependentRequired) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DependentRequired) ProtoMessage() {}
+
+func (x *DependentRequired) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_jsonschema_jsonschema_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DependentRequired.ProtoReflect.Descriptor instead.
+func (*DependentRequired) Descriptor() ([]byte, []int) {
+	return file_mcp_jsonschema_jsonschema_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DependentRequired) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *DependentRequired) GetRequires() []string {
+	if x != nil {
+		return x.Requires
+	}
+	return nil
+}
+
+// 条件必填规则：当 field 的值等于 equals 时，then_required 中的字段必填，
+// 否则 else_required 中的字段必填。字符串和枚举字段直接写值（如 "EXPRESS"），
+// 布尔和数值字段写 JSON 字面量（如 "true"、"3"）
+type ConditionalRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Equals        string                 `protobuf:"bytes,2,opt,name=equals,proto3" json:"equals,omitempty"`
+	ThenRequired  []string               `protobuf:"bytes,3,rep,name=then_required,json=thenRequired,proto3" json:"then_required,omitempty"`
+	ElseRequired  []string               `protobuf:"bytes,4,rep,name=else_required,json=elseRequired,proto3" json:"else_required,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConditionalRule) Reset() {
+	*x = ConditionalRule{}
+	mi := &file_mcp_jsonschema_jsonschema_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConditionalRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConditionalRule) ProtoMessage() {}
+
+func (x *ConditionalRule) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_jsonschema_jsonschema_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConditionalRule.ProtoReflect.Descriptor instead.
+func (*ConditionalRule) Descriptor() ([]byte, []int) {
+	return file_mcp_jsonschema_jsonschema_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ConditionalRule) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ConditionalRule) GetEquals() string {
+	if x != nil {
+		return x.Equals
+	}
+	return ""
+}
+
+func (x *ConditionalRule) GetThenRequired() []string {
+	if x != nil {
+		return x.ThenRequired
+	}
+	return nil
+}
+
+func (x *ConditionalRule) GetElseRequired() []string {
+	if x != nil {
+		return x.ElseRequired
+	}
+	return nil
+}
+
+// 厂商扩展关键字：key 必须以 "x-" 开头，value 为 JSON 值（如 "true"、"\"textarea\""）
+type VendorExtension struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VendorExtension) Reset() {
+	*x = VendorExtension{}
+	mi := &file_mcp_jsonschema_jsonschema_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VendorExtension) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VendorExtension) ProtoMessage() {}
+
+func (x *VendorExtension) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_jsonschema_jsonschema_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VendorExtension.ProtoReflect.Descriptor instead.
+func (*VendorExtension) Descriptor() ([]byte, []int) {
+	return file_mcp_jsonschema_jsonschema_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *VendorExtension) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *VendorExtension) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// 本地化文本：locale 为 BCP 47 语言标签（如 "en"、"zh-CN"），text 为该语言下的文本
+type LocalizedText struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Locale        string                 `protobuf:"bytes,1,opt,name=locale,proto3" json:"locale,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LocalizedText) Reset() {
+	*x = LocalizedText{}
+	mi := &file_mcp_jsonschema_jsonschema_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LocalizedText) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocalizedText) ProtoMessage() {}
+
+func (x *LocalizedText) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_jsonschema_jsonschema_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocalizedText.ProtoReflect.Descriptor instead.
+func (*LocalizedText) Descriptor() ([]byte, []int) {
+	return file_mcp_jsonschema_jsonschema_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LocalizedText) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *LocalizedText) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
 var file_mcp_jsonschema_jsonschema_proto_extTypes = []protoimpl.ExtensionInfo{
 	{
 		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
@@ -118,6 +349,86 @@ var file_mcp_jsonschema_jsonschema_proto_extTypes = []protoimpl.ExtensionInfo{
 		Tag:           "varint,50012,opt,name=required",
 		Filename:      "mcp/jsonschema/jsonschema.proto",
 	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         50013,
+		Name:          "mcp.jsonschema.schema_json",
+		Tag:           "bytes,50013,opt,name=schema_json",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50014,
+		Name:          "mcp.jsonschema.schema_json_replace",
+		Tag:           "varint,50014,opt,name=schema_json_replace",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: ([]*VendorExtension)(nil),
+		Field:         50015,
+		Name:          "mcp.jsonschema.vendor_extension",
+		Tag:           "bytes,50015,rep,name=vendor_extension",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         50016,
+		Name:          "mcp.jsonschema.field_title",
+		Tag:           "bytes,50016,opt,name=field_title",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         50017,
+		Name:          "mcp.jsonschema.deprecation_note",
+		Tag:           "bytes,50017,opt,name=deprecation_note",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         50018,
+		Name:          "mcp.jsonschema.since",
+		Tag:           "bytes,50018,opt,name=since",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         50019,
+		Name:          "mcp.jsonschema.removed_in",
+		Tag:           "bytes,50019,opt,name=removed_in",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: ([]*LocalizedText)(nil),
+		Field:         50020,
+		Name:          "mcp.jsonschema.description_i18n",
+		Tag:           "bytes,50020,rep,name=description_i18n",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: ([]*LocalizedText)(nil),
+		Field:         50021,
+		Name:          "mcp.jsonschema.field_title_i18n",
+		Tag:           "bytes,50021,rep,name=field_title_i18n",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: ([]string)(nil),
+		Field:         50022,
+		Name:          "mcp.jsonschema.audience",
+		Tag:           "bytes,50022,rep,name=audience",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
 	{
 		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
 		ExtensionType: (*string)(nil),
@@ -142,6 +453,198 @@ var file_mcp_jsonschema_jsonschema_proto_extTypes = []protoimpl.ExtensionInfo{
 		Tag:           "bytes,50103,opt,name=title",
 		Filename:      "mcp/jsonschema/jsonschema.proto",
 	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50104,
+		Name:          "mcp.jsonschema.partial",
+		Tag:           "varint,50104,opt,name=partial",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50105,
+		Name:          "mcp.jsonschema.additional_properties",
+		Tag:           "varint,50105,opt,name=additional_properties",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*int32)(nil),
+		Field:         50106,
+		Name:          "mcp.jsonschema.min_properties",
+		Tag:           "varint,50106,opt,name=min_properties",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*int32)(nil),
+		Field:         50107,
+		Name:          "mcp.jsonschema.max_properties",
+		Tag:           "varint,50107,opt,name=max_properties",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: ([]string)(nil),
+		Field:         50108,
+		Name:          "mcp.jsonschema.message_example",
+		Tag:           "bytes,50108,rep,name=message_example",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: ([]*DependentRequired)(nil),
+		Field:         50109,
+		Name:          "mcp.jsonschema.dependent_required",
+		Tag:           "bytes,50109,rep,name=dependent_required",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: ([]*ConditionalRule)(nil),
+		Field:         50110,
+		Name:          "mcp.jsonschema.conditional",
+		Tag:           "bytes,50110,rep,name=conditional",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         50111,
+		Name:          "mcp.jsonschema.message_schema_json",
+		Tag:           "bytes,50111,opt,name=message_schema_json",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50112,
+		Name:          "mcp.jsonschema.message_schema_json_replace",
+		Tag:           "varint,50112,opt,name=message_schema_json_replace",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: ([]*VendorExtension)(nil),
+		Field:         50113,
+		Name:          "mcp.jsonschema.message_vendor_extension",
+		Tag:           "bytes,50113,rep,name=message_vendor_extension",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         50114,
+		Name:          "mcp.jsonschema.message_deprecation_note",
+		Tag:           "bytes,50114,opt,name=message_deprecation_note",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: ([]*LocalizedText)(nil),
+		Field:         50115,
+		Name:          "mcp.jsonschema.message_description_i18n",
+		Tag:           "bytes,50115,rep,name=message_description_i18n",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: ([]*LocalizedText)(nil),
+		Field:         50116,
+		Name:          "mcp.jsonschema.title_i18n",
+		Tag:           "bytes,50116,rep,name=title_i18n",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: ([]string)(nil),
+		Field:         50117,
+		Name:          "mcp.jsonschema.message_audience",
+		Tag:           "bytes,50117,rep,name=message_audience",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MessageOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         50118,
+		Name:          "mcp.jsonschema.message_tool_name",
+		Tag:           "bytes,50118,opt,name=message_tool_name",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.EnumOptions)(nil),
+		ExtensionType: ([]*VendorExtension)(nil),
+		Field:         50201,
+		Name:          "mcp.jsonschema.enum_vendor_extension",
+		Tag:           "bytes,50201,rep,name=enum_vendor_extension",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.EnumValueOptions)(nil),
+		ExtensionType: ([]string)(nil),
+		Field:         50301,
+		Name:          "mcp.jsonschema.enum_value_audience",
+		Tag:           "bytes,50301,rep,name=enum_value_audience",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         50401,
+		Name:          "mcp.jsonschema.tool_name",
+		Tag:           "bytes,50401,opt,name=tool_name",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50402,
+		Name:          "mcp.jsonschema.tool_exclude",
+		Tag:           "varint,50402,opt,name=tool_exclude",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         50403,
+		Name:          "mcp.jsonschema.tool_title",
+		Tag:           "bytes,50403,opt,name=tool_title",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50404,
+		Name:          "mcp.jsonschema.read_only_hint",
+		Tag:           "varint,50404,opt,name=read_only_hint",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50405,
+		Name:          "mcp.jsonschema.destructive_hint",
+		Tag:           "varint,50405,opt,name=destructive_hint",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50406,
+		Name:          "mcp.jsonschema.idempotent_hint",
+		Tag:           "varint,50406,opt,name=idempotent_hint",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50407,
+		Name:          "mcp.jsonschema.open_world_hint",
+		Tag:           "varint,50407,opt,name=open_world_hint",
+		Filename:      "mcp/jsonschema/jsonschema.proto",
+	},
 }
 
 // Extension fields to descriptorpb.FieldOptions.
@@ -194,6 +697,46 @@ var (
 	//
 	// optional bool required = 50012;
 	E_Required = &file_mcp_jsonschema_jsonschema_proto_extTypes[11]
+	// 原始 JSON Schema 片段（JSON 对象），默认深度合并到生成的字段 Schema 中
+	//
+	// optional string schema_json = 50013;
+	E_SchemaJson = &file_mcp_jsonschema_jsonschema_proto_extTypes[12]
+	// 为 true 时用 schema_json 完全替换生成的字段 Schema
+	//
+	// optional bool schema_json_replace = 50014;
+	E_SchemaJsonReplace = &file_mcp_jsonschema_jsonschema_proto_extTypes[13]
+	// 厂商扩展关键字（x-*），原样输出到字段 Schema
+	//
+	// repeated mcp.jsonschema.VendorExtension vendor_extension = 50015;
+	E_VendorExtension = &file_mcp_jsonschema_jsonschema_proto_extTypes[14]
+	// 字段标题
+	//
+	// optional string field_title = 50016;
+	E_FieldTitle = &file_mcp_jsonschema_jsonschema_proto_extTypes[15]
+	// 弃用说明，追加到描述末尾；设置后字段同时标记为 deprecated
+	//
+	// optional string deprecation_note = 50017;
+	E_DeprecationNote = &file_mcp_jsonschema_jsonschema_proto_extTypes[16]
+	// 字段引入的版本，输出为 x-since
+	//
+	// optional string since = 50018;
+	E_Since = &file_mcp_jsonschema_jsonschema_proto_extTypes[17]
+	// 字段计划移除的版本，输出为 x-removed-in
+	//
+	// optional string removed_in = 50019;
+	E_RemovedIn = &file_mcp_jsonschema_jsonschema_proto_extTypes[18]
+	// 多语言字段描述，按生成时选择的语言替换 description
+	//
+	// repeated mcp.jsonschema.LocalizedText description_i18n = 50020;
+	E_DescriptionI18N = &file_mcp_jsonschema_jsonschema_proto_extTypes[19]
+	// 多语言字段标题，按生成时选择的语言替换 field_title
+	//
+	// repeated mcp.jsonschema.LocalizedText field_title_i18n = 50021;
+	E_FieldTitleI18N = &file_mcp_jsonschema_jsonschema_proto_extTypes[20]
+	// 字段可见的受众标签；未设置时对所有受众可见
+	//
+	// repeated string audience = 50022;
+	E_Audience = &file_mcp_jsonschema_jsonschema_proto_extTypes[21]
 )
 
 // Extension fields to descriptorpb.MessageOptions.
@@ -201,22 +744,145 @@ var (
 	// 消息描述
 	//
 	// optional string message_description = 50101;
-	E_MessageDescription = &file_mcp_jsonschema_jsonschema_proto_extTypes[12]
+	E_MessageDescription = &file_mcp_jsonschema_jsonschema_proto_extTypes[22]
 	// 是否生成 Schema（默认 true）
 	//
 	// optional bool generate_schema = 50102;
-	E_GenerateSchema = &file_mcp_jsonschema_jsonschema_proto_extTypes[13]
+	E_GenerateSchema = &file_mcp_jsonschema_jsonschema_proto_extTypes[23]
 	// Schema 标题
 	//
 	// optional string title = 50103;
-	E_Title = &file_mcp_jsonschema_jsonschema_proto_extTypes[14]
+	E_Title = &file_mcp_jsonschema_jsonschema_proto_extTypes[24]
+	// 生成部分（更新）Schema：不输出 required，嵌套消息同样为部分 Schema，
+	// 同级 FieldMask 字段的路径被限定为本消息可更新的字段路径
+	//
+	// optional bool partial = 50104;
+	E_Partial = &file_mcp_jsonschema_jsonschema_proto_extTypes[25]
+	// 是否允许未声明的属性（additionalProperties），未设置时沿用生成器默认值
+	//
+	// optional bool additional_properties = 50105;
+	E_AdditionalProperties = &file_mcp_jsonschema_jsonschema_proto_extTypes[26]
+	// 对象最少属性数
+	//
+	// optional int32 min_properties = 50106;
+	E_MinProperties = &file_mcp_jsonschema_jsonschema_proto_extTypes[27]
+	// 对象最多属性数
+	//
+	// optional int32 max_properties = 50107;
+	E_MaxProperties = &file_mcp_jsonschema_jsonschema_proto_extTypes[28]
+	// 完整的消息示例（JSON 对象字符串），输出到 examples
+	//
+	// repeated string message_example = 50108;
+	E_MessageExample = &file_mcp_jsonschema_jsonschema_proto_extTypes[29]
+	// 字段依赖（dependentRequired）
+	//
+	// repeated mcp.jsonschema.DependentRequired dependent_required = 50109;
+	E_DependentRequired = &file_mcp_jsonschema_jsonschema_proto_extTypes[30]
+	// 条件必填规则（if/then/else）
+	//
+	// repeated mcp.jsonschema.ConditionalRule conditional = 50110;
+	E_Conditional = &file_mcp_jsonschema_jsonschema_proto_extTypes[31]
+	// 原始 JSON Schema 片段（JSON 对象），默认深度合并到生成的消息 Schema 中
+	//
+	// optional string message_schema_json = 50111;
+	E_MessageSchemaJson = &file_mcp_jsonschema_jsonschema_proto_extTypes[32]
+	// 为 true 时用 message_schema_json 完全替换生成的消息 Schema
+	//
+	// optional bool message_schema_json_replace = 50112;
+	E_MessageSchemaJsonReplace = &file_mcp_jsonschema_jsonschema_proto_extTypes[33]
+	// 厂商扩展关键字（x-*），原样输出到消息 Schema
+	//
+	// repeated mcp.jsonschema.VendorExtension message_vendor_extension = 50113;
+	E_MessageVendorExtension = &file_mcp_jsonschema_jsonschema_proto_extTypes[34]
+	// 消息弃用说明，追加到描述末尾；设置后消息同时标记为 deprecated
+	//
+	// optional string message_deprecation_note = 50114;
+	E_MessageDeprecationNote = &file_mcp_jsonschema_jsonschema_proto_extTypes[35]
+	// 多语言消息描述，按生成时选择的语言替换 message_description
+	//
+	// repeated mcp.jsonschema.LocalizedText message_description_i18n = 50115;
+	E_MessageDescriptionI18N = &file_mcp_jsonschema_jsonschema_proto_extTypes[36]
+	// 多语言消息标题，按生成时选择的语言替换 title
+	//
+	// repeated mcp.jsonschema.LocalizedText title_i18n = 50116;
+	E_TitleI18N = &file_mcp_jsonschema_jsonschema_proto_extTypes[37]
+	// 消息可见的受众标签；未设置时对所有受众可见
+	//
+	// repeated string message_audience = 50117;
+	E_MessageAudience = &file_mcp_jsonschema_jsonschema_proto_extTypes[38]
+	// 以该消息作为输入参数生成 LLM 工具定义（format=tools）时的工具名称
+	//
+	// optional string message_tool_name = 50118;
+	E_MessageToolName = &file_mcp_jsonschema_jsonschema_proto_extTypes[39]
+)
+
+// Extension fields to descriptorpb.EnumOptions.
+var (
+	// 厂商扩展关键字（x-*），输出到引用该枚举的字段 Schema
+	//
+	// repeated mcp.jsonschema.VendorExtension enum_vendor_extension = 50201;
+	E_EnumVendorExtension = &file_mcp_jsonschema_jsonschema_proto_extTypes[40]
+)
+
+// Extension fields to descriptorpb.EnumValueOptions.
+var (
+	// 枚举值可见的受众标签；未设置时对所有受众可见
+	//
+	// repeated string enum_value_audience = 50301;
+	E_EnumValueAudience = &file_mcp_jsonschema_jsonschema_proto_extTypes[41]
+)
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// MCP 工具名称，默认为方法名
+	//
+	// optional string tool_name = 50401;
+	E_ToolName = &file_mcp_jsonschema_jsonschema_proto_extTypes[42]
+	// 为 true 时不为该方法生成 MCP 工具
+	//
+	// optional bool tool_exclude = 50402;
+	E_ToolExclude = &file_mcp_jsonschema_jsonschema_proto_extTypes[43]
+	// MCP 工具的显示标题（annotations.title）
+	//
+	// optional string tool_title = 50403;
+	E_ToolTitle = &file_mcp_jsonschema_jsonschema_proto_extTypes[44]
+	// 工具不修改环境（annotations.readOnlyHint）
+	//
+	// optional bool read_only_hint = 50404;
+	E_ReadOnlyHint = &file_mcp_jsonschema_jsonschema_proto_extTypes[45]
+	// 工具可能执行破坏性更新（annotations.destructiveHint）
+	//
+	// optional bool destructive_hint = 50405;
+	E_DestructiveHint = &file_mcp_jsonschema_jsonschema_proto_extTypes[46]
+	// 以相同参数重复调用不会产生额外影响（annotations.idempotentHint）
+	//
+	// optional bool idempotent_hint = 50406;
+	E_IdempotentHint = &file_mcp_jsonschema_jsonschema_proto_extTypes[47]
+	// 工具会与外部实体交互（annotations.openWorldHint）
+	//
+	// optional bool open_world_hint = 50407;
+	E_OpenWorldHint = &file_mcp_jsonschema_jsonschema_proto_extTypes[48]
 )
 
 var File_mcp_jsonschema_jsonschema_proto protoreflect.FileDescriptor
 
 const file_mcp_jsonschema_jsonschema_proto_rawDesc = "" +
 	"\n" +
-	"\x1fmcp/jsonschema/jsonschema.proto\x12\x0emcp.jsonschema\x1a google/protobuf/descriptor.proto:D\n" +
+	"\x1fmcp/jsonschema/jsonschema.proto\x12\x0emcp.jsonschema\x1a google/protobuf/descriptor.proto\"E\n" +
+	"\x11DependentRequired\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1a\n" +
+	"\brequires\x18\x02 \x03(\tR\brequires\"\x89\x01\n" +
+	"\x0fConditionalRule\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x16\n" +
+	"\x06equals\x18\x02 \x01(\tR\x06equals\x12#\n" +
+	"\rthen_required\x18\x03 \x03(\tR\fthenRequired\x12#\n" +
+	"\relse_required\x18\x04 \x03(\tR\felseRequired\"9\n" +
+	"\x0fVendorExtension\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\";\n" +
+	"\rLocalizedText\x12\x16\n" +
+	"\x06locale\x18\x01 \x01(\tR\x06locale\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text:D\n" +
 	"\vdescription\x12\x1d.google.protobuf.FieldOptions\x18ц\x03 \x01(\tR\vdescription\x88\x01\x01:<\n" +
 	"\aexample\x12\x1d.google.protobuf.FieldOptions\x18҆\x03 \x01(\tR\aexample\x88\x01\x01::\n" +
 	"\x06format\x12\x1d.google.protobuf.FieldOptions\x18ӆ\x03 \x01(\tR\x06format\x88\x01\x01:<\n" +
@@ -230,35 +896,137 @@ const file_mcp_jsonschema_jsonschema_proto_rawDesc = "" +
 	"\aminimum\x12\x1d.google.protobuf.FieldOptions\x18ن\x03 \x01(\x01R\aminimum\x88\x01\x01:<\n" +
 	"\amaximum\x12\x1d.google.protobuf.FieldOptions\x18چ\x03 \x01(\x01R\amaximum\x88\x01\x01:<\n" +
 	"\apattern\x12\x1d.google.protobuf.FieldOptions\x18ۆ\x03 \x01(\tR\apattern\x88\x01\x01:>\n" +
-	"\brequired\x12\x1d.google.protobuf.FieldOptions\x18܆\x03 \x01(\bR\brequired\x88\x01\x01:U\n" +
+	"\brequired\x12\x1d.google.protobuf.FieldOptions\x18܆\x03 \x01(\bR\brequired\x88\x01\x01:C\n" +
+	"\vschema_json\x12\x1d.google.protobuf.FieldOptions\x18݆\x03 \x01(\tR\n" +
+	"schemaJson\x88\x01\x01:R\n" +
+	"\x13schema_json_replace\x12\x1d.google.protobuf.FieldOptions\x18ކ\x03 \x01(\bR\x11schemaJsonReplace\x88\x01\x01:k\n" +
+	"\x10vendor_extension\x12\x1d.google.protobuf.FieldOptions\x18߆\x03 \x03(\v2\x1f.mcp.jsonschema.VendorExtensionR\x0fvendorExtension:C\n" +
+	"\vfield_title\x12\x1d.google.protobuf.FieldOptions\x18\xe0\x86\x03 \x01(\tR\n" +
+	"fieldTitle\x88\x01\x01:M\n" +
+	"\x10deprecation_note\x12\x1d.google.protobuf.FieldOptions\x18\xe1\x86\x03 \x01(\tR\x0fdeprecationNote\x88\x01\x01:8\n" +
+	"\x05since\x12\x1d.google.protobuf.FieldOptions\x18\xe2\x86\x03 \x01(\tR\x05since\x88\x01\x01:A\n" +
+	"\n" +
+	"removed_in\x12\x1d.google.protobuf.FieldOptions\x18\xe3\x86\x03 \x01(\tR\tremovedIn\x88\x01\x01:i\n" +
+	"\x10description_i18n\x12\x1d.google.protobuf.FieldOptions\x18\xe4\x86\x03 \x03(\v2\x1d.mcp.jsonschema.LocalizedTextR\x0fdescriptionI18n:h\n" +
+	"\x10field_title_i18n\x12\x1d.google.protobuf.FieldOptions\x18\xe5\x86\x03 \x03(\v2\x1d.mcp.jsonschema.LocalizedTextR\x0efieldTitleI18n:;\n" +
+	"\baudience\x12\x1d.google.protobuf.FieldOptions\x18\xe6\x86\x03 \x03(\tR\baudience:U\n" +
 	"\x13message_description\x12\x1f.google.protobuf.MessageOptions\x18\xb5\x87\x03 \x01(\tR\x12messageDescription\x88\x01\x01:M\n" +
 	"\x0fgenerate_schema\x12\x1f.google.protobuf.MessageOptions\x18\xb6\x87\x03 \x01(\bR\x0egenerateSchema\x88\x01\x01::\n" +
-	"\x05title\x12\x1f.google.protobuf.MessageOptions\x18\xb7\x87\x03 \x01(\tR\x05title\x88\x01\x01BDZBgithub.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema;jsonschemab\x06proto3"
+	"\x05title\x12\x1f.google.protobuf.MessageOptions\x18\xb7\x87\x03 \x01(\tR\x05title\x88\x01\x01:>\n" +
+	"\apartial\x12\x1f.google.protobuf.MessageOptions\x18\xb8\x87\x03 \x01(\bR\apartial\x88\x01\x01:Y\n" +
+	"\x15additional_properties\x12\x1f.google.protobuf.MessageOptions\x18\xb9\x87\x03 \x01(\bR\x14additionalProperties\x88\x01\x01:K\n" +
+	"\x0emin_properties\x12\x1f.google.protobuf.MessageOptions\x18\xba\x87\x03 \x01(\x05R\rminProperties\x88\x01\x01:K\n" +
+	"\x0emax_properties\x12\x1f.google.protobuf.MessageOptions\x18\xbb\x87\x03 \x01(\x05R\rmaxProperties\x88\x01\x01:J\n" +
+	"\x0fmessage_example\x12\x1f.google.protobuf.MessageOptions\x18\xbc\x87\x03 \x03(\tR\x0emessageExample:s\n" +
+	"\x12dependent_required\x12\x1f.google.protobuf.MessageOptions\x18\xbd\x87\x03 \x03(\v2!.mcp.jsonschema.DependentRequiredR\x11dependentRequired:d\n" +
+	"\vconditional\x12\x1f.google.protobuf.MessageOptions\x18\xbe\x87\x03 \x03(\v2\x1f.mcp.jsonschema.ConditionalRuleR\vconditional:T\n" +
+	"\x13message_schema_json\x12\x1f.google.protobuf.MessageOptions\x18\xbf\x87\x03 \x01(\tR\x11messageSchemaJson\x88\x01\x01:c\n" +
+	"\x1bmessage_schema_json_replace\x12\x1f.google.protobuf.MessageOptions\x18\xc0\x87\x03 \x01(\bR\x18messageSchemaJsonReplace\x88\x01\x01:|\n" +
+	"\x18message_vendor_extension\x12\x1f.google.protobuf.MessageOptions\x18\xc1\x87\x03 \x03(\v2\x1f.mcp.jsonschema.VendorExtensionR\x16messageVendorExtension:^\n" +
+	"\x18message_deprecation_note\x12\x1f.google.protobuf.MessageOptions\x18\u0087\x03 \x01(\tR\x16messageDeprecationNote\x88\x01\x01:z\n" +
+	"\x18message_description_i18n\x12\x1f.google.protobuf.MessageOptions\x18Ç\x03 \x03(\v2\x1d.mcp.jsonschema.LocalizedTextR\x16messageDescriptionI18n:_\n" +
+	"\n" +
+	"title_i18n\x12\x1f.google.protobuf.MessageOptions\x18ć\x03 \x03(\v2\x1d.mcp.jsonschema.LocalizedTextR\ttitleI18n:L\n" +
+	"\x10message_audience\x12\x1f.google.protobuf.MessageOptions\x18Ň\x03 \x03(\tR\x0fmessageAudience:P\n" +
+	"\x11message_tool_name\x12\x1f.google.protobuf.MessageOptions\x18Ƈ\x03 \x01(\tR\x0fmessageToolName\x88\x01\x01:s\n" +
+	"\x15enum_vendor_extension\x12\x1c.google.protobuf.EnumOptions\x18\x99\x88\x03 \x03(\v2\x1f.mcp.jsonschema.VendorExtensionR\x13enumVendorExtension:S\n" +
+	"\x13enum_value_audience\x12!.google.protobuf.EnumValueOptions\x18\xfd\x88\x03 \x03(\tR\x11enumValueAudience:@\n" +
+	"\ttool_name\x12\x1e.google.protobuf.MethodOptions\x18\xe1\x89\x03 \x01(\tR\btoolName\x88\x01\x01:F\n" +
+	"\ftool_exclude\x12\x1e.google.protobuf.MethodOptions\x18\xe2\x89\x03 \x01(\bR\vtoolExclude\x88\x01\x01:B\n" +
+	"\n" +
+	"tool_title\x12\x1e.google.protobuf.MethodOptions\x18\xe3\x89\x03 \x01(\tR\ttoolTitle\x88\x01\x01:I\n" +
+	"\x0eread_only_hint\x12\x1e.google.protobuf.MethodOptions\x18\xe4\x89\x03 \x01(\bR\freadOnlyHint\x88\x01\x01:N\n" +
+	"\x10destructive_hint\x12\x1e.google.protobuf.MethodOptions\x18\xe5\x89\x03 \x01(\bR\x0fdestructiveHint\x88\x01\x01:L\n" +
+	"\x0fidempotent_hint\x12\x1e.google.protobuf.MethodOptions\x18\xe6\x89\x03 \x01(\bR\x0eidempotentHint\x88\x01\x01:K\n" +
+	"\x0fopen_world_hint\x12\x1e.google.protobuf.MethodOptions\x18\xe7\x89\x03 \x01(\bR\ropenWorldHint\x88\x01\x01BDZBgithub.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema;jsonschemab\x06proto3"
+
+var (
+	file_mcp_jsonschema_jsonschema_proto_rawDescOnce sync.Once
+	file_mcp_jsonschema_jsonschema_proto_rawDescData []byte
+)
+
+func file_mcp_jsonschema_jsonschema_proto_rawDescGZIP() []byte {
+	file_mcp_jsonschema_jsonschema_proto_rawDescOnce.Do(func() {
+		file_mcp_jsonschema_jsonschema_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_mcp_jsonschema_jsonschema_proto_rawDesc), len(file_mcp_jsonschema_jsonschema_proto_rawDesc)))
+	})
+	return file_mcp_jsonschema_jsonschema_proto_rawDescData
+}
 
+var file_mcp_jsonschema_jsonschema_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_mcp_jsonschema_jsonschema_proto_goTypes = []any{
-	(*descriptorpb.FieldOptions)(nil),   // 0: google.protobuf.FieldOptions
-	(*descriptorpb.MessageOptions)(nil), // 1: google.protobuf.MessageOptions
+	(*DependentRequired)(nil),             // 0: mcp.jsonschema.DependentRequired
+	(*ConditionalRule)(nil),               // 1: mcp.jsonschema.ConditionalRule
+	(*VendorExtension)(nil),               // 2: mcp.jsonschema.VendorExtension
+	(*LocalizedText)(nil),                 // 3: mcp.jsonschema.LocalizedText
+	(*descriptorpb.FieldOptions)(nil),     // 4: google.protobuf.FieldOptions
+	(*descriptorpb.MessageOptions)(nil),   // 5: google.protobuf.MessageOptions
+	(*descriptorpb.EnumOptions)(nil),      // 6: google.protobuf.EnumOptions
+	(*descriptorpb.EnumValueOptions)(nil), // 7: google.protobuf.EnumValueOptions
+	(*descriptorpb.MethodOptions)(nil),    // 8: google.protobuf.MethodOptions
 }
 var file_mcp_jsonschema_jsonschema_proto_depIdxs = []int32{
-	0,  // 0: mcp.jsonschema.description:extendee -> google.protobuf.FieldOptions
-	0,  // 1: mcp.jsonschema.example:extendee -> google.protobuf.FieldOptions
-	0,  // 2: mcp.jsonschema.format:extendee -> google.protobuf.FieldOptions
-	0,  // 3: mcp.jsonschema.default:extendee -> google.protobuf.FieldOptions
-	0,  // 4: mcp.jsonschema.hidden:extendee -> google.protobuf.FieldOptions
-	0,  // 5: mcp.jsonschema.json_name:extendee -> google.protobuf.FieldOptions
-	0,  // 6: mcp.jsonschema.min_length:extendee -> google.protobuf.FieldOptions
-	0,  // 7: mcp.jsonschema.max_length:extendee -> google.protobuf.FieldOptions
-	0,  // 8: mcp.jsonschema.minimum:extendee -> google.protobuf.FieldOptions
-	0,  // 9: mcp.jsonschema.maximum:extendee -> google.protobuf.FieldOptions
-	0,  // 10: mcp.jsonschema.pattern:extendee -> google.protobuf.FieldOptions
-	0,  // 11: mcp.jsonschema.required:extendee -> google.protobuf.FieldOptions
-	1,  // 12: mcp.jsonschema.message_description:extendee -> google.protobuf.MessageOptions
-	1,  // 13: mcp.jsonschema.generate_schema:extendee -> google.protobuf.MessageOptions
-	1,  // 14: mcp.jsonschema.title:extendee -> google.protobuf.MessageOptions
-	15, // [15:15] is the sub-list for method output_type
-	15, // [15:15] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	0,  // [0:15] is the sub-list for extension extendee
+	4,  // 0: mcp.jsonschema.description:extendee -> google.protobuf.FieldOptions
+	4,  // 1: mcp.jsonschema.example:extendee -> google.protobuf.FieldOptions
+	4,  // 2: mcp.jsonschema.format:extendee -> google.protobuf.FieldOptions
+	4,  // 3: mcp.jsonschema.default:extendee -> google.protobuf.FieldOptions
+	4,  // 4: mcp.jsonschema.hidden:extendee -> google.protobuf.FieldOptions
+	4,  // 5: mcp.jsonschema.json_name:extendee -> google.protobuf.FieldOptions
+	4,  // 6: mcp.jsonschema.min_length:extendee -> google.protobuf.FieldOptions
+	4,  // 7: mcp.jsonschema.max_length:extendee -> google.protobuf.FieldOptions
+	4,  // 8: mcp.jsonschema.minimum:extendee -> google.protobuf.FieldOptions
+	4,  // 9: mcp.jsonschema.maximum:extendee -> google.protobuf.FieldOptions
+	4,  // 10: mcp.jsonschema.pattern:extendee -> google.protobuf.FieldOptions
+	4,  // 11: mcp.jsonschema.required:extendee -> google.protobuf.FieldOptions
+	4,  // 12: mcp.jsonschema.schema_json:extendee -> google.protobuf.FieldOptions
+	4,  // 13: mcp.jsonschema.schema_json_replace:extendee -> google.protobuf.FieldOptions
+	4,  // 14: mcp.jsonschema.vendor_extension:extendee -> google.protobuf.FieldOptions
+	4,  // 15: mcp.jsonschema.field_title:extendee -> google.protobuf.FieldOptions
+	4,  // 16: mcp.jsonschema.deprecation_note:extendee -> google.protobuf.FieldOptions
+	4,  // 17: mcp.jsonschema.since:extendee -> google.protobuf.FieldOptions
+	4,  // 18: mcp.jsonschema.removed_in:extendee -> google.protobuf.FieldOptions
+	4,  // 19: mcp.jsonschema.description_i18n:extendee -> google.protobuf.FieldOptions
+	4,  // 20: mcp.jsonschema.field_title_i18n:extendee -> google.protobuf.FieldOptions
+	4,  // 21: mcp.jsonschema.audience:extendee -> google.protobuf.FieldOptions
+	5,  // 22: mcp.jsonschema.message_description:extendee -> google.protobuf.MessageOptions
+	5,  // 23: mcp.jsonschema.generate_schema:extendee -> google.protobuf.MessageOptions
+	5,  // 24: mcp.jsonschema.title:extendee -> google.protobuf.MessageOptions
+	5,  // 25: mcp.jsonschema.partial:extendee -> google.protobuf.MessageOptions
+	5,  // 26: mcp.jsonschema.additional_properties:extendee -> google.protobuf.MessageOptions
+	5,  // 27: mcp.jsonschema.min_properties:extendee -> google.protobuf.MessageOptions
+	5,  // 28: mcp.jsonschema.max_properties:extendee -> google.protobuf.MessageOptions
+	5,  // 29: mcp.jsonschema.message_example:extendee -> google.protobuf.MessageOptions
+	5,  // 30: mcp.jsonschema.dependent_required:extendee -> google.protobuf.MessageOptions
+	5,  // 31: mcp.jsonschema.conditional:extendee -> google.protobuf.MessageOptions
+	5,  // 32: mcp.jsonschema.message_schema_json:extendee -> google.protobuf.MessageOptions
+	5,  // 33: mcp.jsonschema.message_schema_json_replace:extendee -> google.protobuf.MessageOptions
+	5,  // 34: mcp.jsonschema.message_vendor_extension:extendee -> google.protobuf.MessageOptions
+	5,  // 35: mcp.jsonschema.message_deprecation_note:extendee -> google.protobuf.MessageOptions
+	5,  // 36: mcp.jsonschema.message_description_i18n:extendee -> google.protobuf.MessageOptions
+	5,  // 37: mcp.jsonschema.title_i18n:extendee -> google.protobuf.MessageOptions
+	5,  // 38: mcp.jsonschema.message_audience:extendee -> google.protobuf.MessageOptions
+	5,  // 39: mcp.jsonschema.message_tool_name:extendee -> google.protobuf.MessageOptions
+	6,  // 40: mcp.jsonschema.enum_vendor_extension:extendee -> google.protobuf.EnumOptions
+	7,  // 41: mcp.jsonschema.enum_value_audience:extendee -> google.protobuf.EnumValueOptions
+	8,  // 42: mcp.jsonschema.tool_name:extendee -> google.protobuf.MethodOptions
+	8,  // 43: mcp.jsonschema.tool_exclude:extendee -> google.protobuf.MethodOptions
+	8,  // 44: mcp.jsonschema.tool_title:extendee -> google.protobuf.MethodOptions
+	8,  // 45: mcp.jsonschema.read_only_hint:extendee -> google.protobuf.MethodOptions
+	8,  // 46: mcp.jsonschema.destructive_hint:extendee -> google.protobuf.MethodOptions
+	8,  // 47: mcp.jsonschema.idempotent_hint:extendee -> google.protobuf.MethodOptions
+	8,  // 48: mcp.jsonschema.open_world_hint:extendee -> google.protobuf.MethodOptions
+	2,  // 49: mcp.jsonschema.vendor_extension:type_name -> mcp.jsonschema.VendorExtension
+	3,  // 50: mcp.jsonschema.description_i18n:type_name -> mcp.jsonschema.LocalizedText
+	3,  // 51: mcp.jsonschema.field_title_i18n:type_name -> mcp.jsonschema.LocalizedText
+	0,  // 52: mcp.jsonschema.dependent_required:type_name -> mcp.jsonschema.DependentRequired
+	1,  // 53: mcp.jsonschema.conditional:type_name -> mcp.jsonschema.ConditionalRule
+	2,  // 54: mcp.jsonschema.message_vendor_extension:type_name -> mcp.jsonschema.VendorExtension
+	3,  // 55: mcp.jsonschema.message_description_i18n:type_name -> mcp.jsonschema.LocalizedText
+	3,  // 56: mcp.jsonschema.title_i18n:type_name -> mcp.jsonschema.LocalizedText
+	2,  // 57: mcp.jsonschema.enum_vendor_extension:type_name -> mcp.jsonschema.VendorExtension
+	58, // [58:58] is the sub-list for method output_type
+	58, // [58:58] is the sub-list for method input_type
+	49, // [49:58] is the sub-list for extension type_name
+	0,  // [0:49] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
 }
 
@@ -273,12 +1041,13 @@ func file_mcp_jsonschema_jsonschema_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mcp_jsonschema_jsonschema_proto_rawDesc), len(file_mcp_jsonschema_jsonschema_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   0,
-			NumExtensions: 15,
+			NumMessages:   4,
+			NumExtensions: 49,
 			NumServices:   0,
 		},
 		GoTypes:           file_mcp_jsonschema_jsonschema_proto_goTypes,
 		DependencyIndexes: file_mcp_jsonschema_jsonschema_proto_depIdxs,
+		MessageInfos:      file_mcp_jsonschema_jsonschema_proto_msgTypes,
 		ExtensionInfos:    file_mcp_jsonschema_jsonschema_proto_extTypes,
 	}.Build()
 	File_mcp_jsonschema_jsonschema_proto = out.File
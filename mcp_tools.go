@@ -0,0 +1,158 @@
+package jsonschema
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+// MCPTool is an MCP tool definition as listed in a tools/list response
+type MCPTool struct {
+	Name         string              `json:"name"`
+	Description  string              `json:"description,omitempty"`
+	InputSchema  Schema              `json:"inputSchema"`
+	OutputSchema Schema              `json:"outputSchema,omitempty"`
+	Annotations  *MCPToolAnnotations `json:"annotations,omitempty"`
+}
+
+// MCPToolAnnotations are the behavior hints of an MCP tool; unset hints are
+// left to the client's defaults
+type MCPToolAnnotations struct {
+	Title           string `json:"title,omitempty"`
+	ReadOnlyHint    *bool  `json:"readOnlyHint,omitempty"`
+	DestructiveHint *bool  `json:"destructiveHint,omitempty"`
+	IdempotentHint  *bool  `json:"idempotentHint,omitempty"`
+	OpenWorldHint   *bool  `json:"openWorldHint,omitempty"`
+}
+
+// GenerateMCPTools generates an MCP tool for every unary method of the
+// services in files. A tool is named after its method unless the tool_name
+// option renames it, is described by the method's leading comment, takes the
+// schema of the request message as inputSchema and returns the response
+// message, described by outputSchema unless it is google.protobuf.Empty. The
+// tool_title and *_hint method options become its annotations. Methods with
+// the tool_exclude option and streaming methods are left out.
+func (g *Generator) GenerateMCPTools(files ...protoreflect.FileDescriptor) ([]MCPTool, error) {
+	return g.methodTools(files, g.mcpTool)
+}
+
+// methodTools builds a tool with build for every unary method of the services
+// in files that is not excluded, rejecting duplicate tool names
+func (g *Generator) methodTools(files []protoreflect.FileDescriptor, build func(protoreflect.MethodDescriptor, *descriptorpb.MethodOptions) (MCPTool, error)) ([]MCPTool, error) {
+	tools := []MCPTool{}
+	names := map[string]protoreflect.FullName{}
+	for _, file := range files {
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			methods := services.Get(i).Methods()
+			for j := 0; j < methods.Len(); j++ {
+				method := methods.Get(j)
+				opts, _ := method.Options().(*descriptorpb.MethodOptions)
+				if proto.GetExtension(opts, jsonschemapb.E_ToolExclude).(bool) ||
+					method.IsStreamingClient() || method.IsStreamingServer() {
+					continue
+				}
+
+				tool, err := build(method, opts)
+				if err != nil {
+					return nil, err
+				}
+				if other, ok := names[tool.Name]; ok {
+					return nil, fmt.Errorf("%s: tool name %q already used by %s", method.FullName(), tool.Name, other)
+				}
+				names[tool.Name] = method.FullName()
+				tools = append(tools, tool)
+			}
+		}
+	}
+	return tools, nil
+}
+
+// mcpTool generates the MCP tool of method
+func (g *Generator) mcpTool(method protoreflect.MethodDescriptor, opts *descriptorpb.MethodOptions) (MCPTool, error) {
+	tool, err := g.mcpToolInput(method, opts)
+	if err != nil {
+		return MCPTool{}, err
+	}
+
+	tool.Annotations = mcpToolAnnotations(opts)
+	if method.Output().FullName() != emptyFullName {
+		// profiles restrict what a model may send, not what a tool returns,
+		// so the result is described without one. A nil schema leaves the
+		// result unstructured.
+		output := *g
+		output.profile = ""
+		if tool.OutputSchema, err = output.GenerateSchema(method.Output()); err != nil {
+			return MCPTool{}, err
+		}
+	}
+	return tool, nil
+}
+
+// mcpToolInput generates the name, description and inputSchema of the tool
+// of method, leaving the response message alone
+func (g *Generator) mcpToolInput(method protoreflect.MethodDescriptor, opts *descriptorpb.MethodOptions) (MCPTool, error) {
+	name := string(method.Name())
+	if proto.HasExtension(opts, jsonschemapb.E_ToolName) {
+		name = proto.GetExtension(opts, jsonschemapb.E_ToolName).(string)
+	}
+
+	input, err := g.GenerateSchema(method.Input())
+	if err != nil {
+		return MCPTool{}, err
+	}
+	if input == nil {
+		// MCP requires an object schema even when generation is disabled
+		input = Schema{"type": "object"}
+	}
+	return MCPTool{Name: name, Description: leadingComment(method), InputSchema: input}, nil
+}
+
+// emptyFullName is the response type of methods without a result
+const emptyFullName protoreflect.FullName = "google.protobuf.Empty"
+
+// mcpToolAnnotations reads the tool annotations of a method, or nil when it
+// sets none
+func mcpToolAnnotations(opts *descriptorpb.MethodOptions) *MCPToolAnnotations {
+	annotations := &MCPToolAnnotations{}
+	set := false
+	if proto.HasExtension(opts, jsonschemapb.E_ToolTitle) {
+		annotations.Title = proto.GetExtension(opts, jsonschemapb.E_ToolTitle).(string)
+		set = true
+	}
+	for _, hint := range []struct {
+		xt    protoreflect.ExtensionType
+		value **bool
+	}{
+		{jsonschemapb.E_ReadOnlyHint, &annotations.ReadOnlyHint},
+		{jsonschemapb.E_DestructiveHint, &annotations.DestructiveHint},
+		{jsonschemapb.E_IdempotentHint, &annotations.IdempotentHint},
+		{jsonschemapb.E_OpenWorldHint, &annotations.OpenWorldHint},
+	} {
+		if proto.HasExtension(opts, hint.xt) {
+			*hint.value = proto.Bool(proto.GetExtension(opts, hint.xt).(bool))
+			set = true
+		}
+	}
+	if !set {
+		return nil
+	}
+	return annotations
+}
+
+// leadingComment returns the leading comment of d without the space that
+// usually follows each comment marker, or "" when the descriptor carries no
+// source info
+func leadingComment(d protoreflect.Descriptor) string {
+	comment := d.ParentFile().SourceLocations().ByDescriptor(d).LeadingComments
+	lines := strings.Split(strings.TrimSpace(comment), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
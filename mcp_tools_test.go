@@ -0,0 +1,172 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	_ "google.golang.org/protobuf/types/known/emptypb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+// toolMethod builds a method of a synthetic service with the given options
+func toolMethod(name, input, output string, opts *descriptorpb.MethodOptions) *descriptorpb.MethodDescriptorProto {
+	return &descriptorpb.MethodDescriptorProto{
+		Name:       proto.String(name),
+		InputType:  proto.String(input),
+		OutputType: proto.String(output),
+		Options:    opts,
+	}
+}
+
+// serviceFile builds a file in package "test" with messages and a Weather
+// service; comments holds the leading comments of methods by index
+func serviceFile(t *testing.T, messages []*descriptorpb.DescriptorProto, methods []*descriptorpb.MethodDescriptorProto, comments map[int32]string) protoreflect.FileDescriptor {
+	t.Helper()
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:        proto.String(t.Name() + ".proto"),
+		Package:     proto.String("test"),
+		Syntax:      proto.String("proto3"),
+		Dependency:  []string{"google/protobuf/empty.proto", "google/protobuf/timestamp.proto"},
+		MessageType: messages,
+		Service:     []*descriptorpb.ServiceDescriptorProto{{Name: proto.String("Weather"), Method: methods}},
+	}
+	if len(comments) > 0 {
+		fdp.SourceCodeInfo = &descriptorpb.SourceCodeInfo{}
+		for index, comment := range comments {
+			// path of service 0, method index
+			fdp.SourceCodeInfo.Location = append(fdp.SourceCodeInfo.Location, &descriptorpb.SourceCodeInfo_Location{
+				Path:            []int32{6, 0, 2, index},
+				Span:            []int32{0, 0, 0},
+				LeadingComments: proto.String(comment),
+			})
+		}
+	}
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatalf("failed to build test descriptor: %v", err)
+	}
+	return fd
+}
+
+func weatherMessages() []*descriptorpb.DescriptorProto {
+	return []*descriptorpb.DescriptorProto{
+		testMessage("ForecastRequest", nil,
+			testField("city", 1, tString, fieldOpts(ext(jsonschemapb.E_Required, true))),
+			testField("days", 2, tInt32, nil),
+		),
+		testMessage("Forecast", nil, testField("summary", 1, tString, nil)),
+		testMessage("Opaque", msgOpts(ext(jsonschemapb.E_GenerateSchema, false))),
+	}
+}
+
+func TestGenerateMCPTools(t *testing.T) {
+	watch := toolMethod("Watch", ".test.ForecastRequest", ".test.Forecast", nil)
+	watch.ServerStreaming = proto.Bool(true)
+	fd := serviceFile(t, weatherMessages(), []*descriptorpb.MethodDescriptorProto{
+		toolMethod("GetForecast", ".test.ForecastRequest", ".test.Forecast", methodOpts(ext(jsonschemapb.E_ToolName, "get_forecast"))),
+		toolMethod("Internal", ".test.ForecastRequest", ".test.Forecast", methodOpts(ext(jsonschemapb.E_ToolExclude, true))),
+		watch,
+		toolMethod("Reset", ".test.Opaque", ".test.Opaque", nil),
+	}, map[int32]string{0: " Get the forecast for a city.\n\n Returns up to seven days.\n"})
+
+	tools, err := NewGenerator().GenerateMCPTools(fd)
+	if err != nil {
+		t.Fatalf("GenerateMCPTools failed: %v", err)
+	}
+	data, err := json.Marshal(tools)
+	if err != nil {
+		t.Fatalf("failed to marshal tools: %v", err)
+	}
+	want := `[{"name":"get_forecast","description":"Get the forecast for a city.\n\nReturns up to seven days.",` +
+		`"inputSchema":{"properties":{"city":{"type":"string"},"days":{"type":"integer"}},"required":["city"],"title":"ForecastRequest","type":"object"},` +
+		`"outputSchema":{"properties":{"summary":{"type":"string"}},"title":"Forecast","type":"object"}},` +
+		`{"name":"Reset","inputSchema":{"type":"object"}}]`
+	if string(data) != want {
+		t.Errorf("unexpected tools\n got: %s\nwant: %s", data, want)
+	}
+}
+
+func TestGenerateMCPTools_DuplicateName(t *testing.T) {
+	fd := serviceFile(t, weatherMessages(), []*descriptorpb.MethodDescriptorProto{
+		toolMethod("GetForecast", ".test.ForecastRequest", ".test.Forecast", nil),
+		toolMethod("Forecast", ".test.ForecastRequest", ".test.Forecast", methodOpts(ext(jsonschemapb.E_ToolName, "GetForecast"))),
+	}, nil)
+
+	_, err := NewGenerator().GenerateMCPTools(fd)
+	if err == nil || !strings.Contains(err.Error(), `test.Weather.Forecast: tool name "GetForecast" already used by test.Weather.GetForecast`) {
+		t.Errorf("expected duplicate name error, got %v", err)
+	}
+}
+
+func TestGenerateMCPTools_Annotations(t *testing.T) {
+	fd := serviceFile(t, weatherMessages(), []*descriptorpb.MethodDescriptorProto{
+		toolMethod("GetForecast", ".test.ForecastRequest", ".test.Forecast", methodOpts(
+			ext(jsonschemapb.E_ToolTitle, "Weather forecast"),
+			ext(jsonschemapb.E_ReadOnlyHint, true),
+			ext(jsonschemapb.E_OpenWorldHint, true),
+		)),
+		toolMethod("Forget", ".test.ForecastRequest", ".google.protobuf.Empty", methodOpts(
+			ext(jsonschemapb.E_DestructiveHint, true),
+			ext(jsonschemapb.E_IdempotentHint, false),
+		)),
+	}, nil)
+
+	tools, err := NewGenerator().GenerateMCPTools(fd)
+	if err != nil {
+		t.Fatalf("GenerateMCPTools failed: %v", err)
+	}
+	if len(tools) != 2 {
+		t.Fatalf("expected 2 tools, got %d", len(tools))
+	}
+
+	annotations, _ := json.Marshal(tools[0].Annotations)
+	if string(annotations) != `{"title":"Weather forecast","readOnlyHint":true,"openWorldHint":true}` {
+		t.Errorf("unexpected annotations %s", annotations)
+	}
+	annotations, _ = json.Marshal(tools[1].Annotations)
+	if string(annotations) != `{"destructiveHint":true,"idempotentHint":false}` {
+		t.Errorf("expected explicit false hints to be kept, got %s", annotations)
+	}
+	if tools[1].OutputSchema != nil {
+		t.Errorf("expected no outputSchema for google.protobuf.Empty, got %v", tools[1].OutputSchema)
+	}
+}
+
+func TestGenerateMCPTools_ProfileSkipsOutput(t *testing.T) {
+	entry := testMessage("LabelsEntry", &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+		testField("key", 1, tString, nil),
+		testField("value", 2, tString, nil),
+	)
+	report := testMessage("Report", nil,
+		repeated(testMessageField("labels", 1, ".test.Report.LabelsEntry", nil)),
+		testMessageField("forecast", 2, ".test.Forecast", nil),
+	)
+	report.NestedType = []*descriptorpb.DescriptorProto{entry}
+	fd := serviceFile(t, append(weatherMessages(), report), []*descriptorpb.MethodDescriptorProto{
+		toolMethod("GetReport", ".test.ForecastRequest", ".test.Report", nil),
+	}, nil)
+
+	for _, profile := range []Profile{ProfileOpenAIStrict, ProfileMCPElicitation} {
+		g := NewGenerator()
+		g.SetProfile(profile)
+		tools, err := g.GenerateMCPTools(fd)
+		if err != nil {
+			t.Fatalf("%s: GenerateMCPTools failed: %v", profile, err)
+		}
+		output := mustSchemaMap(t, tools[0].OutputSchema)
+		if _, ok := output["properties"].(map[string]interface{})["labels"]; !ok {
+			t.Errorf("%s: expected the response map field in outputSchema, got %v", profile, output)
+		}
+		if _, ok := tools[0].InputSchema["additionalProperties"]; profile == ProfileOpenAIStrict && !ok {
+			t.Errorf("%s: expected the profile to apply to inputSchema, got %v", profile, tools[0].InputSchema)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package jsonschema
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+// NamingStrategy selects the property names used for proto fields
+type NamingStrategy string
+
+const (
+	// NamingJSONName names properties after the lowerCamelCase JSON name, as
+	// protojson marshals by default
+	NamingJSONName NamingStrategy = "json_name"
+	// NamingProtoName names properties after the proto field name, as
+	// protojson marshals with UseProtoNames
+	NamingProtoName NamingStrategy = "proto_name"
+	// NamingBoth accepts each field under either name, but not both at once,
+	// as protojson unmarshals
+	NamingBoth NamingStrategy = "both"
+)
+
+// SetNamingStrategy sets how property names are derived from fields. The
+// json_name option always takes precedence. The zero value is NamingJSONName.
+func (g *Generator) SetNamingStrategy(strategy NamingStrategy) {
+	g.naming = strategy
+}
+
+// NamingStrategy returns the strategy set by SetNamingStrategy
+func (g *Generator) NamingStrategy() NamingStrategy {
+	if g.naming == "" {
+		return NamingJSONName
+	}
+	return g.naming
+}
+
+// IsValid reports whether s is one of the defined naming strategies
+func (s NamingStrategy) IsValid() bool {
+	switch s {
+	case NamingJSONName, NamingProtoName, NamingBoth:
+		return true
+	}
+	return false
+}
+
+// fieldNames returns the property names of field under the naming strategy,
+// primary name first. Only NamingBoth yields a second name, and only when the
+// proto name differs from the JSON name.
+func (g *Generator) fieldNames(field protoreflect.FieldDescriptor, fieldOpts *descriptorpb.FieldOptions) []string {
+	if proto.HasExtension(fieldOpts, jsonschemapb.E_JsonName) {
+		return []string{proto.GetExtension(fieldOpts, jsonschemapb.E_JsonName).(string)}
+	}
+	protoName := string(field.Name())
+	jsonName := field.JSONName()
+	if jsonName == "" {
+		jsonName = protoName
+	}
+
+	switch g.NamingStrategy() {
+	case NamingProtoName:
+		return []string{protoName}
+	case NamingBoth:
+		if jsonName != protoName {
+			return []string{jsonName, protoName}
+		}
+	}
+	return []string{jsonName}
+}
+
+// alternativeNames constrains the names a field is accepted under to be
+// mutually exclusive: exactly one of them when the field is required, at most
+// one otherwise
+func alternativeNames(names []string, required bool) Schema {
+	if !required {
+		return Schema{"not": Schema{"required": names}}
+	}
+	oneOf := make([]interface{}, len(names))
+	for i, name := range names {
+		oneOf[i] = Schema{"required": []string{name}}
+	}
+	return Schema{"oneOf": oneOf}
+}
@@ -0,0 +1,97 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+func namingMessage(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+	fd := testFile(t,
+		testMessage("User", nil,
+			testField("user_name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(ext(jsonschemapb.E_Required, true))),
+			testField("email_address", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+			testField("id", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+			testField("nick", 4, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(ext(jsonschemapb.E_JsonName, "nickname"))),
+		),
+	)
+	return fd.Messages().ByName("User")
+}
+
+func TestNamingStrategy_Properties(t *testing.T) {
+	md := namingMessage(t)
+
+	tests := []struct {
+		strategy   NamingStrategy
+		properties []string
+		required   []string
+	}{
+		{"", []string{"emailAddress", "id", "nickname", "userName"}, []string{"userName"}},
+		{NamingJSONName, []string{"emailAddress", "id", "nickname", "userName"}, []string{"userName"}},
+		{NamingProtoName, []string{"email_address", "id", "nickname", "user_name"}, []string{"user_name"}},
+		{NamingBoth, []string{"emailAddress", "email_address", "id", "nickname", "userName", "user_name"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			g := NewGenerator()
+			g.SetNamingStrategy(tt.strategy)
+			schema, err := g.GenerateSchema(md)
+			if err != nil {
+				t.Fatalf("GenerateSchema failed: %v", err)
+			}
+			m := mustSchemaMap(t, schema)
+			var names []string
+			for name := range m["properties"].(map[string]interface{}) {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			if !reflect.DeepEqual(names, tt.properties) {
+				t.Errorf("properties = %v, want %v", names, tt.properties)
+			}
+			required, _ := stringList(m["required"])
+			if !reflect.DeepEqual(required, tt.required) {
+				t.Errorf("required = %v, want %v", required, tt.required)
+			}
+		})
+	}
+}
+
+func TestNamingStrategy_BothIsMutuallyExclusive(t *testing.T) {
+	g := NewGeneratorWithOptions(true)
+	g.SetNamingStrategy(NamingBoth)
+	ordered, err := g.GenerateOrderedSchema(namingMessage(t))
+	if err != nil {
+		t.Fatalf("GenerateOrderedSchema failed: %v", err)
+	}
+	data, err := json.Marshal(ordered)
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+	for _, want := range []string{
+		`"properties":{"userName":{"type":"string"},"user_name":{"type":"string"},"emailAddress"`,
+		`"allOf":[{"oneOf":[{"required":["userName"]},{"required":["user_name"]}]},{"not":{"required":["emailAddress","email_address"]}}]`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %s in %s", want, data)
+		}
+	}
+}
+
+func TestNamingStrategy_IsValid(t *testing.T) {
+	for _, s := range []NamingStrategy{NamingJSONName, NamingProtoName, NamingBoth} {
+		if !s.IsValid() {
+			t.Errorf("expected %q to be valid", s)
+		}
+	}
+	if NamingStrategy("camel").IsValid() {
+		t.Error("expected unknown strategy to be invalid")
+	}
+}
@@ -0,0 +1,124 @@
+package jsonschema
+
+import (
+	"fmt"
+	"sort"
+)
+
+// strictKeywords are the keywords kept by the openai_strict profile; the
+// remaining validation keywords (format, minLength, pattern, ...) are not
+// supported by every model and are dropped
+var strictKeywords = map[string]bool{
+	"type": true, "properties": true, "required": true, "additionalProperties": true,
+	"items": true, "enum": true, "const": true, "anyOf": true,
+	"title": true, "description": true, "$ref": true, "$defs": true,
+}
+
+// strictRejected are keywords whose constraints strict mode cannot express;
+// dropping them would silently accept documents the schema rejects
+var strictRejected = []string{
+	"allOf", "not", "if", "then", "else",
+	"dependentRequired", "dependentSchemas", "dependencies", "patternProperties",
+}
+
+// strictSchema rewrites schema, found at path, and its subschemas for OpenAI
+// strict mode:
+//
+//   - every object lists all of its properties in required, and those that
+//     were optional become nullable instead
+//   - every object sets additionalProperties: false, so maps and free-form
+//     objects, which would only accept {}, are an error
+//   - oneOf becomes anyOf and unsupported keywords are dropped
+//
+// order, if any, lists the property names of schema in field order.
+func strictSchema(schema map[string]interface{}, path string, order []string) error {
+	for _, keyword := range strictRejected {
+		if _, ok := schema[keyword]; ok {
+			return fmt.Errorf("%s: %s cannot be expressed in strict mode", path, keyword)
+		}
+	}
+	if oneOf, ok := schema["oneOf"]; ok {
+		if _, exists := schema["anyOf"]; !exists {
+			schema["anyOf"] = oneOf
+		}
+	}
+	for keyword := range schema {
+		if !strictKeywords[keyword] {
+			delete(schema, keyword)
+		}
+	}
+
+	properties, hasProperties := asObject(schema["properties"])
+	if hasProperties || schema["type"] == "object" {
+		// message schemas always list their properties, so an object without
+		// them is a map or a free-form value such as google.protobuf.Struct
+		additional, ok := schema["additionalProperties"]
+		if (ok && additional != false) || (!ok && !hasProperties) {
+			return fmt.Errorf("%s: maps and free-form objects cannot be expressed in strict mode", path)
+		}
+		schema["additionalProperties"] = false
+	}
+	if hasProperties {
+		for name, property := range properties {
+			if sub, ok := asObject(property); ok {
+				if err := strictSchema(sub, path+"/properties/"+name, nil); err != nil {
+					return err
+				}
+			}
+		}
+
+		required, _ := stringList(schema["required"])
+		listed := map[string]bool{}
+		for _, name := range required {
+			listed[name] = true
+		}
+		for _, name := range strictPropertyOrder(properties, order) {
+			if listed[name] {
+				continue
+			}
+			if property, ok := asObject(properties[name]); ok {
+				properties[name] = nullableSchema(property)
+			}
+			required = append(required, name)
+			listed[name] = true
+		}
+		schema["required"] = required
+	}
+
+	if items, ok := asObject(schema["items"]); ok {
+		if err := strictSchema(items, path+"/items", nil); err != nil {
+			return err
+		}
+	}
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok {
+		for i, branch := range anyOf {
+			if sub, ok := asObject(branch); ok {
+				if err := strictSchema(sub, fmt.Sprintf("%s/anyOf/%d", path, i), nil); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// strictPropertyOrder lists the names of properties in field order when order
+// is known, and sorted otherwise so the output is stable
+func strictPropertyOrder(properties map[string]interface{}, order []string) []string {
+	names := make([]string, 0, len(properties))
+	seen := map[string]bool{}
+	for _, name := range order {
+		if _, ok := properties[name]; ok && !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+	var rest []string
+	for name := range properties {
+		if !seen[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
+}
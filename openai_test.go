@@ -0,0 +1,130 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+func strictGenerator() *Generator {
+	g := NewGenerator()
+	g.SetProfile(ProfileOpenAIStrict)
+	return g
+}
+
+func orderFile(t *testing.T) protoreflect.FileDescriptor {
+	t.Helper()
+	return testFile(t,
+		testMessage("Order", nil,
+			testField("id", 1, tString, fieldOpts(ext(jsonschemapb.E_Required, true), ext(jsonschemapb.E_Format, "uuid"))),
+			testField("note", 2, tString, fieldOpts(ext(jsonschemapb.E_MaxLength, int32(200)), ext(jsonschemapb.E_Example, "leave at door"))),
+			testMessageField("ship_to", 3, ".test.Address", fieldOpts(ext(jsonschemapb.E_Description, "Delivery address"))),
+			repeated(testMessageField("stops", 4, ".test.Address", nil)),
+			testMessageField("created", 5, ".google.protobuf.Timestamp", nil),
+		),
+		testMessage("Address", nil, testField("city", 1, tString, fieldOpts(ext(jsonschemapb.E_Required, true)))),
+	)
+}
+
+func TestOpenAIStrict_Rewrite(t *testing.T) {
+	schema, err := strictGenerator().GenerateSchema(orderFile(t).Messages().ByName("Order"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	m := mustSchemaMap(t, schema)
+
+	if m["additionalProperties"] != false {
+		t.Errorf("expected additionalProperties false, got %v", m["additionalProperties"])
+	}
+	if required, _ := json.Marshal(m["required"]); string(required) != `["id","note","shipTo","stops","created"]` {
+		t.Errorf("expected every property required, explicit ones first, got %s", required)
+	}
+
+	props := m["properties"].(map[string]interface{})
+	assertKeywords(t, props["id"], `{"type":"string"}`)
+	assertKeywords(t, props["note"], `{"type":["string","null"]}`)
+	assertKeywords(t, props["shipTo"], `{"additionalProperties":false,"description":"Delivery address",`+
+		`"properties":{"city":{"type":"string"}},"required":["city"],"title":"Address","type":["object","null"]}`)
+	assertKeywords(t, props["stops"], `{"items":{"additionalProperties":false,`+
+		`"properties":{"city":{"type":"string"}},"required":["city"],"title":"Address","type":"object"},"type":["array","null"]}`)
+
+	created := props["created"].(map[string]interface{})
+	if _, ok := created["oneOf"]; ok {
+		t.Error("expected oneOf to be rewritten to anyOf")
+	}
+	if branches, _ := created["anyOf"].([]interface{}); len(branches) != 3 {
+		t.Errorf("expected both Timestamp forms and a null branch, got %v", created["anyOf"])
+	}
+}
+
+func TestOpenAIStrict_OrderedSchema(t *testing.T) {
+	g := strictGenerator()
+	g.SetPreserveOrder(true)
+	ordered, err := g.GenerateOrderedSchema(orderFile(t).Messages().ByName("Order"))
+	if err != nil {
+		t.Fatalf("GenerateOrderedSchema failed: %v", err)
+	}
+	if strings.Join(ordered.Required, ",") != "id,note,shipTo,stops,created" {
+		t.Errorf("expected required in field order, got %v", ordered.Required)
+	}
+	if ordered.AdditionalProperties == nil || *ordered.AdditionalProperties {
+		t.Error("expected additionalProperties false")
+	}
+}
+
+func TestOpenAIStrict_Unexpressible(t *testing.T) {
+	entry := testMessage("LabelsEntry", &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+		testField("key", 1, tString, nil),
+		testField("value", 2, tString, nil),
+	)
+	labels := testMessage("Tagged", nil, repeated(testMessageField("labels", 1, ".test.Tagged.LabelsEntry", nil)))
+	labels.NestedType = []*descriptorpb.DescriptorProto{entry}
+
+	fd := testFile(t,
+		testMessage("Node", nil,
+			testField("value", 1, tString, nil),
+			testMessageField("next", 2, ".test.Node", nil),
+		),
+		testMessage("Tree", nil, repeated(testMessageField("nodes", 1, ".test.Node", nil))),
+		labels,
+		testMessage("Shipping", msgOpts(ext(jsonschemapb.E_DependentRequired, []*jsonschemapb.DependentRequired{
+			{Field: "express", Requires: []string{"phone"}},
+		})),
+			testField("express", 1, tBool, nil),
+			testField("phone", 2, tString, nil),
+		),
+	)
+
+	for _, tc := range []struct {
+		message protoreflect.Name
+		want    string
+	}{
+		{"Node", "test.Node: openai_strict profile: recursive message test.Node cannot be inlined: test.Node -> test.Node"},
+		{"Tree", "recursive message test.Node cannot be inlined: test.Node -> test.Node"},
+		{"Tagged", "#/properties/labels/items: maps and free-form objects cannot be expressed in strict mode"},
+		{"Shipping", "#: dependentRequired cannot be expressed in strict mode"},
+	} {
+		_, err := strictGenerator().GenerateSchema(fd.Messages().ByName(tc.message))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.message, tc.want, err)
+		}
+	}
+}
+
+func TestOpenAIStrict_LeavesDefaultUnchanged(t *testing.T) {
+	schema, err := NewGenerator().GenerateSchema(orderFile(t).Messages().ByName("Order"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	props := mustSchemaMap(t, schema)["properties"].(map[string]interface{})
+	assertKeywords(t, props["shipTo"], `{"description":"Delivery address","type":"object"}`)
+	if !Profile("openai_strict").IsValid() || Profile("strict").IsValid() {
+		t.Error("unexpected profile validity")
+	}
+}
@@ -0,0 +1,381 @@
+package jsonschema
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// OpenAPIVersion selects the OpenAPI version of GenerateOpenAPI documents
+type OpenAPIVersion string
+
+// Supported OpenAPI versions
+const (
+	OpenAPI30 OpenAPIVersion = "3.0"
+	OpenAPI31 OpenAPIVersion = "3.1"
+)
+
+// openAPIRefPrefix is where OpenAPI documents keep message schemas
+const openAPIRefPrefix = "#/components/schemas/"
+
+// googleAPIHTTPExt is the google.api.http method annotation, resolved by name
+// like the validation extensions
+const googleAPIHTTPExt protoreflect.FullName = "google.api.http"
+
+// IsValid reports whether v is one of the supported OpenAPI versions
+func (v OpenAPIVersion) IsValid() bool {
+	return v == OpenAPI30 || v == OpenAPI31
+}
+
+// GenerateOpenAPI generates an OpenAPI document whose components.schemas hold
+// the schema of every message of files that has schema generation enabled,
+// plus the messages they reference, keyed by full name. Message fields refer
+// to each other with $ref, and fields declared optional are nullable: with
+// nullable in 3.0 and a "null" type in 3.1. Methods annotated with
+// google.api.http get a minimal paths entry.
+func (g *Generator) GenerateOpenAPI(version OpenAPIVersion, title string, files ...protoreflect.FileDescriptor) (Schema, error) {
+	if !version.IsValid() {
+		return nil, fmt.Errorf("unknown OpenAPI version: %s", version)
+	}
+
+	gen := *g
+	gen.refPrefix = openAPIRefPrefix
+	gen.nullable = true
+	gen.dialect = ""
+	gen.profile = ""
+
+	var queue []protoreflect.MessageDescriptor
+	for _, file := range files {
+		messages := file.Messages()
+		for i := 0; i < messages.Len(); i++ {
+			md := messages.Get(i)
+			if ShouldGenerateSchema(md.Options().(*descriptorpb.MessageOptions)) {
+				queue = append(queue, md)
+			}
+		}
+	}
+
+	paths := Schema{}
+	for _, file := range files {
+		services := file.Services()
+		for i := 0; i < services.Len(); i++ {
+			methods := services.Get(i).Methods()
+			for j := 0; j < methods.Len(); j++ {
+				method := methods.Get(j)
+				operations, err := gen.openAPIOperations(version, method)
+				if err != nil {
+					return nil, err
+				}
+				if len(operations) == 0 {
+					continue
+				}
+				queue = append(queue, method.Input(), method.Output())
+				for _, op := range operations {
+					queue = append(queue, op.refs...)
+					item, _ := paths[op.path].(Schema)
+					if item == nil {
+						item = Schema{}
+						paths[op.path] = item
+					}
+					item[op.method] = op.operation
+				}
+			}
+		}
+	}
+
+	schemas := Schema{}
+	for len(queue) > 0 {
+		md := queue[0]
+		queue = queue[1:]
+		name := string(md.FullName())
+		if _, done := schemas[name]; done {
+			continue
+		}
+
+		schema, err := gen.GenerateSchema(md)
+		if err != nil {
+			return nil, err
+		}
+		if schema == nil {
+			// referenced, but generation is disabled for it
+			schema = Schema{"type": "object", "title": string(md.Name())}
+		}
+		openAPISchema(version, schema)
+		schemas[name] = schema
+		queue = append(queue, gen.referencedMessages(md)...)
+	}
+
+	openapi := "3.0.3"
+	if version == OpenAPI31 {
+		openapi = "3.1.0"
+	}
+	return Schema{
+		"openapi":    openapi,
+		"info":       Schema{"title": title, "version": "0.0.0"},
+		"paths":      paths,
+		"components": Schema{"schemas": schemas},
+	}, nil
+}
+
+// openAPISchema rewrites a generated schema in place to the keywords of the
+// given OpenAPI version
+func openAPISchema(version OpenAPIVersion, schema Schema) {
+	switch version {
+	case OpenAPI30:
+		toOpenAPI30(schema)
+	case OpenAPI31:
+		rewriteDialect(schema, Dialect202012)
+	}
+}
+
+// refMessage returns the message a field refers to with $ref when the
+// generator emits references: message fields other than maps, well-known
+// types and mapped types
+func (g *Generator) refMessage(field protoreflect.FieldDescriptor) (protoreflect.MessageDescriptor, bool) {
+	if g.refPrefix == "" || field.Kind() != protoreflect.MessageKind || field.IsMap() {
+		return nil, false
+	}
+	if _, mapped := g.typeMapper(field); mapped || isWellKnownType(field.Message()) {
+		return nil, false
+	}
+	return field.Message(), true
+}
+
+// referencedMessages lists the messages the visible fields of md refer to
+func (g *Generator) referencedMessages(md protoreflect.MessageDescriptor) []protoreflect.MessageDescriptor {
+	var refs []protoreflect.MessageDescriptor
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if !g.isFieldVisible(field) {
+			continue
+		}
+		if ref, ok := g.refMessage(field); ok {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// nullableSchema lets schema also accept null: a "null" type is added to
+// typed schemas (and null to their enum), a null branch to anyOf schemas, and
+// other schemas are wrapped in anyOf
+func nullableSchema(schema Schema) Schema {
+	typ, ok := schema["type"].(string)
+	if !ok {
+		if anyOf, ok := schema["anyOf"].([]interface{}); ok {
+			schema["anyOf"] = append(anyOf, Schema{"type": "null"})
+			return schema
+		}
+		return Schema{"anyOf": []interface{}{schema, Schema{"type": "null"}}}
+	}
+	schema["type"] = []interface{}{typ, "null"}
+	if names, ok := stringList(schema["enum"]); ok {
+		enum := make([]interface{}, 0, len(names)+1)
+		for _, name := range names {
+			enum = append(enum, name)
+		}
+		schema["enum"] = append(enum, nil)
+	}
+	return schema
+}
+
+// toOpenAPI30 rewrites schema and its subschemas into OpenAPI 3.0 Schema
+// Objects: draft-04 keywords, a single example, nullable instead of "null"
+// types, and $refs without siblings
+func toOpenAPI30(schema map[string]interface{}) {
+	rewriteDialect(schema, DialectDraft04)
+	toOpenAPI30Keywords(schema)
+}
+
+func toOpenAPI30Keywords(schema map[string]interface{}) {
+	if examples, ok := schema["examples"].([]interface{}); ok {
+		if _, exists := schema["example"]; !exists && len(examples) > 0 {
+			schema["example"] = examples[0]
+		}
+		delete(schema, "examples")
+	}
+	// dependencies has no OpenAPI 3.0 equivalent
+	delete(schema, "dependencies")
+
+	if types, ok := schema["type"].([]interface{}); ok {
+		var kept []interface{}
+		for _, t := range types {
+			if t == "null" {
+				schema["nullable"] = true
+			} else {
+				kept = append(kept, t)
+			}
+		}
+		if len(kept) == 1 {
+			schema["type"] = kept[0]
+		} else {
+			schema["type"] = kept
+		}
+	}
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok {
+		var kept []interface{}
+		for _, branch := range anyOf {
+			if sub, ok := asObject(branch); ok && len(sub) == 1 && sub["type"] == "null" {
+				schema["nullable"] = true
+				continue
+			}
+			kept = append(kept, branch)
+		}
+		delete(schema, "anyOf")
+		if len(kept) == 1 {
+			schema["allOf"] = append(asList(schema["allOf"]), kept[0])
+		} else if len(kept) > 1 {
+			schema["anyOf"] = kept
+		}
+	}
+	if ref, ok := schema["$ref"]; ok && len(schema) > 1 {
+		delete(schema, "$ref")
+		schema["allOf"] = append([]interface{}{Schema{"$ref": ref}}, asList(schema["allOf"])...)
+	}
+
+	forEachSubschema(schema, toOpenAPI30Keywords)
+}
+
+// asList returns value as a JSON array, or nil
+func asList(value interface{}) []interface{} {
+	list, _ := value.([]interface{})
+	return list
+}
+
+// openAPIOperation is one path operation derived from an HTTP rule
+type openAPIOperation struct {
+	path, method string
+	operation    Schema
+	// refs lists the messages the request body refers to besides the input
+	refs []protoreflect.MessageDescriptor
+}
+
+// pathParam matches the variables of an HTTP rule path template, such as
+// {name} and {name=projects/*}
+var pathParam = regexp.MustCompile(`\{([^}=]+)(=[^}]*)?\}`)
+
+// openAPIOperations derives the operations of method from its google.api.http
+// annotation and additional bindings
+func (g *Generator) openAPIOperations(version OpenAPIVersion, method protoreflect.MethodDescriptor) ([]openAPIOperation, error) {
+	rule, err := extensionMessage(method.ParentFile(), method.Options(), googleAPIHTTPExt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid %s option: %w", method.FullName(), googleAPIHTTPExt, err)
+	}
+	if rule == nil {
+		return nil, nil
+	}
+
+	rules := []protoreflect.Message{rule}
+	if value, ok := ruleValue(rule, "additional_bindings"); ok {
+		list := value.List()
+		for i := 0; i < list.Len(); i++ {
+			rules = append(rules, list.Get(i).Message())
+		}
+	}
+
+	var operations []openAPIOperation
+	for i, rule := range rules {
+		httpMethod, template := httpRulePattern(rule)
+		if template == "" {
+			continue
+		}
+		operationID := string(method.Parent().Name()) + "_" + string(method.Name())
+		if i > 0 {
+			operationID += fmt.Sprint(i + 1)
+		}
+		operation := Schema{
+			"operationId": operationID,
+			"responses": Schema{
+				"200": Schema{
+					"description": "OK",
+					"content":     jsonContent(g.refPrefix + string(method.Output().FullName())),
+				},
+			},
+		}
+
+		var params []interface{}
+		for _, match := range pathParam.FindAllStringSubmatch(template, -1) {
+			params = append(params, Schema{
+				"name":     match[1],
+				"in":       "path",
+				"required": true,
+				"schema":   Schema{"type": "string"},
+			})
+		}
+		if len(params) > 0 {
+			operation["parameters"] = params
+		}
+		var refs []protoreflect.MessageDescriptor
+		if body := ruleString(rule, "body"); body != "" {
+			content := jsonContent(g.refPrefix + string(method.Input().FullName()))
+			if body != "*" {
+				var err error
+				content, refs, err = g.bodyFieldContent(version, method, body)
+				if err != nil {
+					return nil, err
+				}
+			}
+			operation["requestBody"] = Schema{"required": true, "content": content}
+		}
+
+		operations = append(operations, openAPIOperation{
+			path:      pathParam.ReplaceAllString(template, "{$1}"),
+			method:    httpMethod,
+			operation: operation,
+			refs:      refs,
+		})
+	}
+	return operations, nil
+}
+
+// bodyFieldContent is the request body content of an HTTP rule whose body is
+// the named field of the method's input. A message field is referenced as a
+// component, which is also returned so that it gets generated; other fields,
+// such as scalars, well-known types and lists, have their schema inlined.
+func (g *Generator) bodyFieldContent(version OpenAPIVersion, method protoreflect.MethodDescriptor, body string) (Schema, []protoreflect.MessageDescriptor, error) {
+	field := method.Input().Fields().ByName(protoreflect.Name(body))
+	if field == nil {
+		return nil, nil, fmt.Errorf("%s: %s body references unknown field %q of %s", method.FullName(), googleAPIHTTPExt, body, method.Input().FullName())
+	}
+
+	ref, isRef := g.refMessage(field)
+	if isRef && !field.IsList() {
+		return jsonContent(g.refPrefix + string(ref.FullName())), []protoreflect.MessageDescriptor{ref}, nil
+	}
+	schema, err := g.generateFieldSchema(field, field.Options().(*descriptorpb.FieldOptions))
+	if err != nil {
+		return nil, nil, err
+	}
+	openAPISchema(version, schema)
+	var refs []protoreflect.MessageDescriptor
+	if isRef {
+		refs = append(refs, ref)
+	}
+	return Schema{"application/json": Schema{"schema": schema}}, refs, nil
+}
+
+// httpRulePattern returns the lowercase HTTP method and path template of an
+// HttpRule
+func httpRulePattern(rule protoreflect.Message) (string, string) {
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete, http.MethodPatch} {
+		name := strings.ToLower(method)
+		if path := ruleString(rule, protoreflect.Name(name)); path != "" {
+			return name, path
+		}
+	}
+	if value, ok := ruleValue(rule, "custom"); ok {
+		custom := value.Message()
+		return strings.ToLower(ruleString(custom, "kind")), ruleString(custom, "path")
+	}
+	return "", ""
+}
+
+// jsonContent is an application/json content map with a schema reference
+func jsonContent(ref string) Schema {
+	return Schema{"application/json": Schema{"schema": Schema{"$ref": ref}}}
+}
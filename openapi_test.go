@@ -0,0 +1,287 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+// httpFile is a subset of google/api/http.proto and annotations.proto with
+// the upstream field names and numbers
+var httpFile = mustFile(&descriptorpb.FileDescriptorProto{
+	Name:       proto.String("google/api/http.proto"),
+	Package:    proto.String("google.api"),
+	Syntax:     proto.String("proto3"),
+	Dependency: []string{"google/protobuf/descriptor.proto"},
+	MessageType: []*descriptorpb.DescriptorProto{
+		ruleMessage("HttpRule", []string{"pattern"},
+			ruleField("get", 2, tString, "", proto.Int32(0)),
+			ruleField("put", 3, tString, "", proto.Int32(0)),
+			ruleField("post", 4, tString, "", proto.Int32(0)),
+			ruleField("delete", 5, tString, "", proto.Int32(0)),
+			ruleField("patch", 6, tString, "", proto.Int32(0)),
+			ruleField("custom", 8, tMessage, ".google.api.CustomHttpPattern", proto.Int32(0)),
+			ruleField("body", 7, tString, "", nil),
+			ruleList(ruleField("additional_bindings", 11, tMessage, ".google.api.HttpRule", nil)),
+		),
+		ruleMessage("CustomHttpPattern", nil,
+			ruleField("kind", 1, tString, "", nil),
+			ruleField("path", 2, tString, "", nil),
+		),
+	},
+	Extension: []*descriptorpb.FieldDescriptorProto{
+		ruleExtension("http", 72295728, tMessage, ".google.api.HttpRule", ".google.protobuf.MethodOptions"),
+	},
+})
+
+// httpMethod builds a method annotated with the google.api.http rule given as
+// protojson text, or an unannotated method when text is empty
+func httpMethod(t *testing.T, name, input, output, text string) *descriptorpb.MethodDescriptorProto {
+	t.Helper()
+	opts := &descriptorpb.MethodOptions{}
+	if text != "" {
+		rule := dynamicpb.NewMessage(httpFile.Messages().ByName("HttpRule"))
+		if err := protojson.Unmarshal([]byte(text), rule); err != nil {
+			t.Fatalf("invalid HttpRule %s: %v", text, err)
+		}
+		opts = withUnknownMessage(t, opts, 72295728, rule)
+	}
+	return &descriptorpb.MethodDescriptorProto{
+		Name:       proto.String(name),
+		InputType:  proto.String(input),
+		OutputType: proto.String(output),
+		Options:    opts,
+	}
+}
+
+// optional marks a proto3 field of message as declared optional
+func optional(message *descriptorpb.DescriptorProto, field *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+	field.Proto3Optional = proto.Bool(true)
+	field.OneofIndex = proto.Int32(int32(len(message.OneofDecl)))
+	message.OneofDecl = append(message.OneofDecl, &descriptorpb.OneofDescriptorProto{Name: proto.String("_" + field.GetName())})
+	message.Field = append(message.Field, field)
+	return field
+}
+
+// shopFile builds a file with a Shop service whose methods carry HTTP rules
+func shopFile(t *testing.T) protoreflect.FileDescriptor {
+	t.Helper()
+	item := testMessage("Item", msgOpts(ext(jsonschemapb.E_MessageExample, []string{`{"name": "book"}`})),
+		testField("name", 1, tString, nil),
+		testMessageField("price", 2, ".test.Item.Price", fieldOpts(ext(jsonschemapb.E_Description, "Unit price"))),
+		repeated(testMessageField("history", 3, ".test.Item.Price", nil)),
+	)
+	optional(item, testField("count", 4, tInt32, nil))
+	item.NestedType = []*descriptorpb.DescriptorProto{
+		testMessage("Price", nil, testField("amount", 1, tDouble, nil)),
+	}
+
+	return shopService(t, []*descriptorpb.DescriptorProto{
+		item,
+		testMessage("GetItemRequest", nil, testField("name", 1, tString, nil)),
+		testMessage("UpdateItemRequest", nil,
+			testField("name", 1, tString, nil),
+			testMessageField("item", 2, ".test.Item", nil),
+		),
+		testMessage("Internal", msgOpts(ext(jsonschemapb.E_GenerateSchema, false))),
+	},
+		httpMethod(t, "GetItem", ".test.GetItemRequest", ".test.Item", `{"get": "/v1/{name=items/*}"}`),
+		httpMethod(t, "UpdateItem", ".test.UpdateItemRequest", ".test.Item",
+			`{"patch": "/v1/{name=items/*}", "body": "item", "additionalBindings": [{"post": "/v1/items:update", "body": "*"}]}`),
+		httpMethod(t, "Purge", ".test.GetItemRequest", ".test.Internal", `{"custom": {"kind": "PURGE", "path": "/v1/items"}}`),
+		httpMethod(t, "Ping", ".test.GetItemRequest", ".test.GetItemRequest", ""),
+	)
+}
+
+// shopService builds a file with the given messages and a Shop service with
+// the given methods, importing the HTTP annotations and field_mask.proto
+func shopService(t *testing.T, messages []*descriptorpb.DescriptorProto, methods ...*descriptorpb.MethodDescriptorProto) protoreflect.FileDescriptor {
+	t.Helper()
+	files := new(protoregistry.Files)
+	for _, dep := range []protoreflect.FileDescriptor{httpFile, fieldmaskpb.File_google_protobuf_field_mask_proto} {
+		if err := files.RegisterFile(dep); err != nil {
+			t.Fatalf("register %s: %v", dep.Path(), err)
+		}
+	}
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:        proto.String(t.Name() + ".proto"),
+		Package:     proto.String("test"),
+		Syntax:      proto.String("proto3"),
+		Dependency:  []string{httpFile.Path(), "google/protobuf/field_mask.proto"},
+		MessageType: messages,
+		Service:     []*descriptorpb.ServiceDescriptorProto{{Name: proto.String("Shop"), Method: methods}},
+	}, files)
+	if err != nil {
+		t.Fatalf("failed to build test descriptor: %v", err)
+	}
+	return fd
+}
+
+func openAPIDocument(t *testing.T, version OpenAPIVersion) map[string]interface{} {
+	t.Helper()
+	doc, err := NewGenerator().GenerateOpenAPI(version, "test", shopFile(t))
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI failed: %v", err)
+	}
+	return mustSchemaMap(t, doc)
+}
+
+func componentSchema(t *testing.T, doc map[string]interface{}, name string) map[string]interface{} {
+	t.Helper()
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	schema, ok := schemas[name].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected component %s, got %v", name, schemas)
+	}
+	return schema
+}
+
+func TestGenerateOpenAPI_Components31(t *testing.T) {
+	doc := openAPIDocument(t, OpenAPI31)
+	if doc["openapi"] != "3.1.0" {
+		t.Errorf("expected openapi 3.1.0, got %v", doc["openapi"])
+	}
+
+	props := componentSchema(t, doc, "test.Item")["properties"].(map[string]interface{})
+	assertKeywords(t, props["price"], `{"$ref":"#/components/schemas/test.Item.Price","description":"Unit price"}`)
+	assertKeywords(t, props["history"], `{"items":{"$ref":"#/components/schemas/test.Item.Price"},"type":"array"}`)
+	assertKeywords(t, props["count"], `{"type":["integer","null"]}`)
+
+	// nested messages are only included because they are referenced
+	assertKeywords(t, componentSchema(t, doc, "test.Item.Price")["properties"], `{"amount":{"type":"number"}}`)
+	// referenced messages with generation disabled get a placeholder
+	assertKeywords(t, componentSchema(t, doc, "test.Internal"), `{"title":"Internal","type":"object"}`)
+	if _, ok := componentSchema(t, doc, "test.Item")["$schema"]; ok {
+		t.Error("components must not carry $schema")
+	}
+}
+
+func TestGenerateOpenAPI_Components30(t *testing.T) {
+	doc := openAPIDocument(t, OpenAPI30)
+	if doc["openapi"] != "3.0.3" {
+		t.Errorf("expected openapi 3.0.3, got %v", doc["openapi"])
+	}
+
+	item := componentSchema(t, doc, "test.Item")
+	if example, _ := json.Marshal(item["example"]); string(example) != `{"name":"book"}` {
+		t.Errorf("expected the first message example as example, got %s", example)
+	}
+	if _, ok := item["examples"]; ok {
+		t.Error("OpenAPI 3.0 schemas must not carry examples")
+	}
+
+	props := item["properties"].(map[string]interface{})
+	assertKeywords(t, props["price"], `{"allOf":[{"$ref":"#/components/schemas/test.Item.Price"}],"description":"Unit price"}`)
+	assertKeywords(t, props["count"], `{"nullable":true,"type":"integer"}`)
+}
+
+func TestGenerateOpenAPI_Paths(t *testing.T) {
+	doc := openAPIDocument(t, OpenAPI31)
+	paths := doc["paths"].(map[string]interface{})
+	if len(paths) != 3 {
+		t.Errorf("expected paths for annotated methods only, got %v", paths)
+	}
+
+	item := paths["/v1/{name}"].(map[string]interface{})
+	assertKeywords(t, item["get"], `{"operationId":"Shop_GetItem",`+
+		`"parameters":[{"in":"path","name":"name","required":true,"schema":{"type":"string"}}],`+
+		`"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/test.Item"}}},"description":"OK"}}}`)
+
+	patch := item["patch"].(map[string]interface{})
+	assertKeywords(t, patch["requestBody"], `{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/test.Item"}}},"required":true}`)
+
+	post := paths["/v1/items:update"].(map[string]interface{})["post"].(map[string]interface{})
+	if post["operationId"] != "Shop_UpdateItem2" {
+		t.Errorf("expected additional bindings to get numbered operation ids, got %v", post["operationId"])
+	}
+	assertKeywords(t, post["requestBody"], `{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/test.UpdateItemRequest"}}},"required":true}`)
+
+	if _, ok := paths["/v1/items"].(map[string]interface{})["purge"]; !ok {
+		t.Errorf("expected custom pattern under its kind, got %v", paths["/v1/items"])
+	}
+}
+
+func TestGenerateOpenAPI_BodyFields(t *testing.T) {
+	fd := shopService(t, []*descriptorpb.DescriptorProto{
+		testMessage("PatchRequest", nil,
+			testField("name", 1, tString, nil),
+			testMessageField("mask", 2, ".google.protobuf.FieldMask", nil),
+			testMessageField("price", 3, ".test.Price", nil),
+		),
+		testMessage("Price", nil, testField("amount", 1, tDouble, nil)),
+		testMessage("Empty", nil),
+	},
+		httpMethod(t, "Rename", ".test.PatchRequest", ".test.Empty", `{"post": "/v1/rename", "body": "name"}`),
+		httpMethod(t, "Mask", ".test.PatchRequest", ".test.Empty", `{"post": "/v1/mask", "body": "mask"}`),
+		httpMethod(t, "Reprice", ".test.PatchRequest", ".test.Empty", `{"post": "/v1/reprice", "body": "price"}`),
+	)
+	doc, err := NewGenerator().GenerateOpenAPI(OpenAPI30, "test", fd)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI failed: %v", err)
+	}
+	m := mustSchemaMap(t, doc)
+	paths := m["paths"].(map[string]interface{})
+	requestBody := func(path string) interface{} {
+		return paths[path].(map[string]interface{})["post"].(map[string]interface{})["requestBody"]
+	}
+
+	assertKeywords(t, requestBody("/v1/rename"), `{"content":{"application/json":{"schema":{"type":"string"}}},"required":true}`)
+	assertKeywords(t, requestBody("/v1/mask"), `{"content":{"application/json":{"schema":{"type":"object"}}},"required":true}`)
+	assertKeywords(t, requestBody("/v1/reprice"), `{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/test.Price"}}},"required":true}`)
+	componentSchema(t, m, "test.Price")
+}
+
+func TestGenerateOpenAPI_UnknownBodyField(t *testing.T) {
+	fd := shopService(t, []*descriptorpb.DescriptorProto{testMessage("Empty", nil)},
+		httpMethod(t, "Touch", ".test.Empty", ".test.Empty", `{"post": "/v1/touch", "body": "missing"}`),
+	)
+	_, err := NewGenerator().GenerateOpenAPI(OpenAPI31, "test", fd)
+	if err == nil || !strings.Contains(err.Error(), `unknown field "missing"`) {
+		t.Errorf("expected unknown body field error, got %v", err)
+	}
+}
+
+func TestGenerateOpenAPI_NoServices(t *testing.T) {
+	fd := testFile(t, testMessage("Book", nil, testField("title", 1, tString, nil)))
+	doc, err := NewGenerator().GenerateOpenAPI(OpenAPI30, "test", fd)
+	if err != nil {
+		t.Fatalf("GenerateOpenAPI failed: %v", err)
+	}
+	m := mustSchemaMap(t, doc)
+	assertKeywords(t, m["paths"], `{}`)
+	componentSchema(t, m, "test.Book")
+}
+
+func TestGenerateOpenAPI_PlainSchemasUnchanged(t *testing.T) {
+	g := NewGenerator()
+	if _, err := g.GenerateOpenAPI(OpenAPI31, "test", shopFile(t)); err != nil {
+		t.Fatalf("GenerateOpenAPI failed: %v", err)
+	}
+	schema, err := g.GenerateSchema(shopFile(t).Messages().ByName("Item"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	props := mustSchemaMap(t, schema)["properties"].(map[string]interface{})
+	assertKeywords(t, props["count"], `{"type":"integer"}`)
+	if _, ok := props["price"].(map[string]interface{})["$ref"]; ok {
+		t.Error("GenerateSchema must keep inlining message fields")
+	}
+}
+
+func TestGenerateOpenAPI_UnknownVersion(t *testing.T) {
+	_, err := NewGenerator().GenerateOpenAPI("2.0", "test", shopFile(t))
+	if err == nil || !strings.Contains(err.Error(), "unknown OpenAPI version") {
+		t.Errorf("expected unknown version error, got %v", err)
+	}
+}
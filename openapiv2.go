@@ -0,0 +1,161 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Annotations of grpc-gateway's protoc-gen-openapiv2, resolved by name like
+// the validation extensions
+const (
+	openapiv2FieldExt  protoreflect.FullName = "grpc.gateway.protoc_gen_openapiv2.options.openapiv2_field"
+	openapiv2SchemaExt protoreflect.FullName = "grpc.gateway.protoc_gen_openapiv2.options.openapiv2_schema"
+)
+
+// applyOpenAPIv2Field fills schema from the openapiv2_field annotation of
+// field. It runs before every other source, so validation rules and explicit
+// options override what it sets.
+func applyOpenAPIv2Field(field protoreflect.FieldDescriptor, schema Schema) error {
+	jsonSchema, err := extensionMessage(field.ParentFile(), field.Options(), openapiv2FieldExt)
+	if err != nil {
+		return fmt.Errorf("%s: invalid %s option: %w", field.FullName(), openapiv2FieldExt, err)
+	}
+	if jsonSchema == nil {
+		return nil
+	}
+	applyOpenAPIv2JSONSchema(jsonSchema, schema)
+	return nil
+}
+
+// applyOpenAPIv2Message fills the title, description, readOnly and examples
+// of a message schema from its openapiv2_schema annotation where the message
+// options leave them unset
+func applyOpenAPIv2Message(md protoreflect.MessageDescriptor, schema Schema, hasTitle bool) error {
+	annotation, err := extensionMessage(md.ParentFile(), md.Options(), openapiv2SchemaExt)
+	if err != nil {
+		return fmt.Errorf("%s: invalid %s option: %w", md.FullName(), openapiv2SchemaExt, err)
+	}
+	if annotation == nil {
+		return nil
+	}
+
+	if value, ok := ruleValue(annotation, "json_schema"); ok {
+		jsonSchema := value.Message()
+		if title := ruleString(jsonSchema, "title"); title != "" && !hasTitle {
+			schema["title"] = title
+		}
+		if description := ruleString(jsonSchema, "description"); description != "" {
+			if _, ok := schema["description"]; !ok {
+				schema["description"] = description
+			}
+		}
+	}
+	if value, ok := ruleValue(annotation, "read_only"); ok && value.Bool() {
+		schema["readOnly"] = true
+	}
+	if example := ruleString(annotation, "example"); example != "" {
+		schema["examples"] = []interface{}{openAPIv2Value(example)}
+	}
+	return nil
+}
+
+// applyOpenAPIv2JSONSchema copies the keywords of an openapiv2 JSONSchema
+// annotation into schema
+func applyOpenAPIv2JSONSchema(jsonSchema protoreflect.Message, schema Schema) {
+	for name, keyword := range map[protoreflect.Name]string{
+		"title":       "title",
+		"description": "description",
+		"pattern":     "pattern",
+		"format":      "format",
+	} {
+		if value := ruleString(jsonSchema, name); value != "" {
+			schema[keyword] = value
+		}
+	}
+	if value, ok := ruleValue(jsonSchema, "read_only"); ok && value.Bool() {
+		schema["readOnly"] = true
+	}
+	if example := ruleString(jsonSchema, "example"); example != "" {
+		schema["examples"] = []interface{}{openAPIv2Value(example)}
+	}
+	if def := ruleString(jsonSchema, "default"); def != "" {
+		schema["default"] = openAPIv2Value(def)
+	}
+
+	for name, keyword := range map[protoreflect.Name]string{
+		"min_length": "minLength",
+		"max_length": "maxLength",
+		"min_items":  "minItems",
+		"max_items":  "maxItems",
+	} {
+		if value, ok := ruleValue(jsonSchema, name); ok && value.Uint() > 0 {
+			schema[keyword] = int64(value.Uint())
+		}
+	}
+	for _, bound := range []struct {
+		name, exclusive           protoreflect.Name
+		keyword, exclusiveKeyword string
+	}{
+		{"minimum", "exclusive_minimum", "minimum", "exclusiveMinimum"},
+		{"maximum", "exclusive_maximum", "maximum", "exclusiveMaximum"},
+	} {
+		value, ok := ruleValue(jsonSchema, bound.name)
+		if !ok || value.Float() == 0 {
+			continue
+		}
+		keyword := bound.keyword
+		if exclusive, ok := ruleValue(jsonSchema, bound.exclusive); ok && exclusive.Bool() {
+			keyword = bound.exclusiveKeyword
+		}
+		schema[keyword] = value.Float()
+	}
+	if value, ok := ruleValue(jsonSchema, "unique_items"); ok && value.Bool() {
+		schema["uniqueItems"] = true
+	}
+	if values := listValues(jsonSchema, "enum"); len(values) > 0 {
+		schema["enum"] = values
+	}
+}
+
+// isOpenAPIv2Required reports whether field is listed as required by the
+// openapiv2_field annotation of field itself or by the json_schema.required
+// list of its message's openapiv2_schema annotation
+func isOpenAPIv2Required(field protoreflect.FieldDescriptor) bool {
+	names := map[string]bool{string(field.Name()): true, field.JSONName(): true}
+
+	if jsonSchema, err := extensionMessage(field.ParentFile(), field.Options(), openapiv2FieldExt); err == nil && jsonSchema != nil {
+		for _, name := range listValues(jsonSchema, "required") {
+			if names[name.(string)] {
+				return true
+			}
+		}
+	}
+
+	md := field.ContainingMessage()
+	annotation, err := extensionMessage(md.ParentFile(), md.Options(), openapiv2SchemaExt)
+	if err != nil || annotation == nil {
+		return false
+	}
+	value, ok := ruleValue(annotation, "json_schema")
+	if !ok {
+		return false
+	}
+	for _, name := range listValues(value.Message(), "required") {
+		if names[name.(string)] {
+			return true
+		}
+	}
+	return false
+}
+
+// openAPIv2Value decodes an openapiv2 example or default, which holds a JSON
+// value; text that is not valid JSON is taken as a plain string
+func openAPIv2Value(text string) interface{} {
+	var value interface{}
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		return text
+	}
+	return value
+}
@@ -0,0 +1,147 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+// openapiv2File is a subset of protoc-gen-openapiv2/options/openapiv2.proto
+// with the upstream field names and numbers
+var openapiv2File = mustFile(&descriptorpb.FileDescriptorProto{
+	Name:       proto.String("protoc-gen-openapiv2/options/openapiv2.proto"),
+	Package:    proto.String("grpc.gateway.protoc_gen_openapiv2.options"),
+	Syntax:     proto.String("proto3"),
+	Dependency: []string{"google/protobuf/descriptor.proto"},
+	MessageType: []*descriptorpb.DescriptorProto{
+		ruleMessage("Schema", nil,
+			ruleField("json_schema", 1, tMessage, ".grpc.gateway.protoc_gen_openapiv2.options.JSONSchema", nil),
+			ruleField("read_only", 3, tBool, "", nil),
+			ruleField("example", 6, tString, "", nil),
+		),
+		ruleMessage("JSONSchema", nil,
+			ruleField("title", 5, tString, "", nil),
+			ruleField("description", 6, tString, "", nil),
+			ruleField("default", 7, tString, "", nil),
+			ruleField("read_only", 8, tBool, "", nil),
+			ruleField("example", 9, tString, "", nil),
+			ruleField("maximum", 11, tDouble, "", nil),
+			ruleField("exclusive_maximum", 12, tBool, "", nil),
+			ruleField("minimum", 13, tDouble, "", nil),
+			ruleField("max_length", 15, tUint64, "", nil),
+			ruleField("min_length", 16, tUint64, "", nil),
+			ruleField("pattern", 17, tString, "", nil),
+			ruleList(ruleField("required", 26, tString, "", nil)),
+			ruleField("format", 36, tString, "", nil),
+		),
+	},
+	Extension: []*descriptorpb.FieldDescriptorProto{
+		ruleExtension("openapiv2_schema", 1042, tMessage, ".grpc.gateway.protoc_gen_openapiv2.options.Schema", ".google.protobuf.MessageOptions"),
+		ruleExtension("openapiv2_field", 1042, tMessage, ".grpc.gateway.protoc_gen_openapiv2.options.JSONSchema", ".google.protobuf.FieldOptions"),
+	},
+})
+
+// openapiv2Annotation builds an openapiv2 message from its protojson text
+func openapiv2Annotation(t *testing.T, name protoreflect.Name, text string) *dynamicpb.Message {
+	t.Helper()
+	msg := dynamicpb.NewMessage(openapiv2File.Messages().ByName(name))
+	if err := protojson.Unmarshal([]byte(text), msg); err != nil {
+		t.Fatalf("invalid %s %s: %v", name, text, err)
+	}
+	return msg
+}
+
+func openapiv2FieldOpts(t *testing.T, opts *descriptorpb.FieldOptions, text string) *descriptorpb.FieldOptions {
+	t.Helper()
+	return withUnknownMessage(t, opts, 1042, openapiv2Annotation(t, "JSONSchema", text))
+}
+
+func openapiv2PetFile(t *testing.T) protoreflect.FileDescriptor {
+	t.Helper()
+	schemaOpts := withUnknownMessage(t, &descriptorpb.MessageOptions{}, 1042, openapiv2Annotation(t, "Schema",
+		`{"jsonSchema": {"title": "A pet", "description": "A pet in the store", "required": ["name"]}, "example": "{\"name\": \"Rex\"}"}`))
+	return fileImporting(t, []protoreflect.FileDescriptor{openapiv2File}, nil,
+		testMessage("Pet", schemaOpts,
+			testField("id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, openapiv2FieldOpts(t, &descriptorpb.FieldOptions{},
+				`{"title": "ID", "description": "Server-assigned id", "readOnly": true, "format": "uuid", "example": "\"7d444840\""}`)),
+			testField("name", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, openapiv2FieldOpts(t, &descriptorpb.FieldOptions{},
+				`{"pattern": "^[A-Z]", "minLength": "1", "maxLength": "30"}`)),
+			testField("age", 3, descriptorpb.FieldDescriptorProto_TYPE_INT32, openapiv2FieldOpts(t,
+				fieldOpts(ext(jsonschemapb.E_Description, "Age in years"), ext(jsonschemapb.E_Example, "3")),
+				`{"description": "ignored", "maximum": 40, "exclusiveMaximum": true, "example": "5", "required": ["age"]}`)),
+			testField("nickname", 4, descriptorpb.FieldDescriptorProto_TYPE_STRING, openapiv2FieldOpts(t,
+				fieldOpts(ext(jsonschemapb.E_Required, false)), `{"required": ["nickname"]}`)),
+		),
+	)
+}
+
+func TestOpenAPIv2_OptIn(t *testing.T) {
+	schema, err := NewGenerator().GenerateSchema(openapiv2PetFile(t).Messages().ByName("Pet"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	if schema["title"] != "Pet" {
+		t.Errorf("openapiv2 annotations must be ignored unless enabled, got title %v", schema["title"])
+	}
+	if _, ok := schema["required"]; ok {
+		t.Error("openapiv2 annotations must be ignored unless enabled")
+	}
+}
+
+func TestOpenAPIv2_Fallback(t *testing.T) {
+	g := NewGenerator()
+	g.SetOpenAPIv2(true)
+	schema, err := g.GenerateSchema(openapiv2PetFile(t).Messages().ByName("Pet"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	m := mustSchemaMap(t, schema)
+
+	if m["title"] != "A pet" || m["description"] != "A pet in the store" {
+		t.Errorf("expected message title and description from openapiv2_schema, got %v / %v", m["title"], m["description"])
+	}
+	if examples, _ := json.Marshal(m["examples"]); string(examples) != `[{"name":"Rex"}]` {
+		t.Errorf("expected message example from openapiv2_schema, got %s", examples)
+	}
+	if required, _ := json.Marshal(m["required"]); string(required) != `["name","age"]` {
+		t.Errorf("expected required from openapiv2 annotations, got %s", required)
+	}
+
+	props := m["properties"].(map[string]interface{})
+	assertKeywords(t, props["id"], `{"description":"Server-assigned id","examples":["7d444840"],"format":"uuid","readOnly":true,"title":"ID","type":"string"}`)
+	assertKeywords(t, props["name"], `{"maxLength":30,"minLength":1,"pattern":"^[A-Z]","type":"string"}`)
+	assertKeywords(t, props["age"], `{"description":"Age in years","example":"3","exclusiveMaximum":40,"type":"integer"}`)
+}
+
+func TestOpenAPIv2_MessageOptionsWin(t *testing.T) {
+	schemaOpts := withUnknownMessage(t, msgOpts(
+		ext(jsonschemapb.E_Title, "Pet"),
+		ext(jsonschemapb.E_MessageDescription, "Explicit"),
+		ext(jsonschemapb.E_MessageExample, []string{`{"name": "Fido"}`}),
+	), 1042, openapiv2Annotation(t, "Schema", `{"jsonSchema": {"title": "A pet", "description": "A pet in the store"}, "example": "{}"}`))
+	fd := fileImporting(t, []protoreflect.FileDescriptor{openapiv2File}, nil,
+		testMessage("Pet", schemaOpts, testField("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil)),
+	)
+
+	g := NewGeneratorWithOptions(true)
+	g.SetOpenAPIv2(true)
+	ordered, err := g.GenerateOrderedSchema(fd.Messages().ByName("Pet"))
+	if err != nil {
+		t.Fatalf("GenerateOrderedSchema failed: %v", err)
+	}
+	data, err := json.Marshal(ordered)
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+	want := `{"type":"object","title":"Pet","description":"Explicit","properties":{"name":{"type":"string"}},"examples":[{"name":"Fido"}]}`
+	if string(data) != want {
+		t.Errorf("unexpected ordered schema\n got: %s\nwant: %s", data, want)
+	}
+}
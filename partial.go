@@ -0,0 +1,182 @@
+package jsonschema
+
+import (
+	"regexp"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+const fieldMaskFullName protoreflect.FullName = "google.protobuf.FieldMask"
+
+// partialScope carries the state of a partial (update) schema walk: the
+// messages currently being expanded, so recursive message types terminate.
+type partialScope struct {
+	expanding map[protoreflect.FullName]bool
+}
+
+// newPartialScope returns a scope for md when it is generated as a partial
+// schema, either through the partial message option or SetPartial, and nil
+// otherwise.
+func (g *Generator) newPartialScope(md protoreflect.MessageDescriptor, msgOpts *descriptorpb.MessageOptions) *partialScope {
+	if !g.partial && !isPartialMessage(msgOpts) {
+		return nil
+	}
+	return &partialScope{expanding: map[protoreflect.FullName]bool{md.FullName(): true}}
+}
+
+// isPartialMessage reports whether the partial message option is set
+func isPartialMessage(msgOpts *descriptorpb.MessageOptions) bool {
+	if proto.HasExtension(msgOpts, jsonschemapb.E_Partial) {
+		return proto.GetExtension(msgOpts, jsonschemapb.E_Partial).(bool)
+	}
+	return false
+}
+
+// applyPartial rewrites a field schema for a partial schema: nested messages
+// are expanded inline with their own (partial) properties, and a FieldMask
+// next to a single resource field is restricted to that resource's paths.
+// Fields whose schema_json replaces the generated schema are left untouched.
+func (g *Generator) applyPartial(field protoreflect.FieldDescriptor, siblings protoreflect.FieldDescriptors, schema Schema, scope *partialScope) error {
+	if field.Kind() != protoreflect.MessageKind || field.IsMap() ||
+		isReplaceSchemaJSON(field.Options(), jsonschemapb.E_SchemaJsonReplace) {
+		return nil
+	}
+
+	md := field.Message()
+	if md.FullName() == fieldMaskFullName {
+		if !field.IsList() {
+			g.applyFieldMaskPaths(schema, siblings)
+		}
+		return nil
+	}
+	if _, mapped := g.typeMapper(field); mapped || isWellKnownType(md) || scope.expanding[md.FullName()] {
+		return nil
+	}
+
+	target := schema
+	if field.IsList() {
+		// items replaced by a non-object schema_json value are left as they are
+		var ok bool
+		if target, ok = asObject(schema["items"]); !ok {
+			return nil
+		}
+	}
+	// partial schemas are expanded inline instead of referring to the full one
+	if _, ok := target["$ref"]; ok {
+		delete(target, "$ref")
+		target["type"] = "object"
+	}
+
+	scope.expanding[md.FullName()] = true
+	fields, err := g.processFields(md.Fields(), scope)
+	delete(scope.expanding, md.FullName())
+	if err != nil {
+		return err
+	}
+
+	// keep property overrides a schema_json fragment merged into the field
+	if existing, ok := asObject(target["properties"]); ok {
+		deepMerge(fields.properties, existing)
+	}
+	fields.apply(target)
+	g.applyObjectConstraints(target, md.Options().(*descriptorpb.MessageOptions))
+	return nil
+}
+
+// applyFieldMaskPaths renders a FieldMask as its protojson string form and,
+// when exactly one resource message sits next to it, constrains the
+// comma-separated paths to the resource's field paths.
+func (g *Generator) applyFieldMaskPaths(schema Schema, siblings protoreflect.FieldDescriptors) {
+	schema["type"] = "string"
+
+	var resource protoreflect.FieldDescriptor
+	for i := 0; i < siblings.Len(); i++ {
+		sibling := siblings.Get(i)
+		if !g.isResourceField(sibling) || !g.isFieldVisible(sibling) {
+			continue
+		}
+		if resource != nil {
+			return
+		}
+		resource = sibling
+	}
+	if resource == nil {
+		return
+	}
+
+	paths := g.fieldMaskPaths(resource.Message(), "", map[protoreflect.FullName]bool{})
+	if _, ok := schema["pattern"]; ok || len(paths) == 0 {
+		return
+	}
+
+	quoted := make([]string, len(paths))
+	for i, path := range paths {
+		quoted[i] = regexp.QuoteMeta(path)
+	}
+	alternatives := "(?:" + strings.Join(quoted, "|") + ")"
+	schema["pattern"] = "^" + alternatives + "(?:," + alternatives + ")*$"
+}
+
+// fieldMaskPaths lists the visible field paths of md in the lowerCamelCase
+// form protojson uses for FieldMask, descending into singular nested messages.
+func (g *Generator) fieldMaskPaths(md protoreflect.MessageDescriptor, prefix string, expanding map[protoreflect.FullName]bool) []string {
+	expanding[md.FullName()] = true
+	defer delete(expanding, md.FullName())
+
+	var paths []string
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if !g.isFieldVisible(field) {
+			continue
+		}
+
+		path := prefix + jsonCamelCase(string(field.Name()))
+		paths = append(paths, path)
+
+		if g.isResourceField(field) && !expanding[field.Message().FullName()] {
+			paths = append(paths, g.fieldMaskPaths(field.Message(), path+".", expanding)...)
+		}
+	}
+	return paths
+}
+
+// isResourceField reports whether field is a singular message that is neither
+// a well-known type nor mapped to a custom schema by a registered type mapper
+func (g *Generator) isResourceField(field protoreflect.FieldDescriptor) bool {
+	if _, mapped := g.typeMapper(field); mapped {
+		return false
+	}
+	return field.Kind() == protoreflect.MessageKind &&
+		field.Cardinality() != protoreflect.Repeated &&
+		!isWellKnownType(field.Message())
+}
+
+// isWellKnownType reports whether md is a google.protobuf well-known type,
+// which protojson maps to a special JSON form rather than a plain object
+func isWellKnownType(md protoreflect.MessageDescriptor) bool {
+	return md.ParentFile().Package() == "google.protobuf"
+}
+
+// jsonCamelCase converts a proto field name to the lowerCamelCase form used by
+// protojson for FieldMask paths (mirrors protobuf's internal strs.JSONCamelCase).
+func jsonCamelCase(s string) string {
+	var b []byte
+	var wasUnderscore bool
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '_' {
+			if wasUnderscore && 'a' <= c && c <= 'z' {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+		}
+		wasUnderscore = c == '_'
+	}
+	return string(b)
+}
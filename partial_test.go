@@ -0,0 +1,170 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+func TestPartial_MessageOption(t *testing.T) {
+	fd := testFile(t,
+		testMessage("Book", nil,
+			testField("title", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(ext(jsonschemapb.E_Required, true))),
+		),
+		testMessage("UpdateBookRequest", msgOpts(ext(jsonschemapb.E_Partial, true)),
+			testMessageField("book", 1, ".test.Book", fieldOpts(ext(jsonschemapb.E_Required, true))),
+			testMessageField("update_mask", 2, ".google.protobuf.FieldMask", nil),
+		),
+	)
+
+	schema, err := NewGenerator().GenerateSchema(fd.Messages().ByName("UpdateBookRequest"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	m := mustSchemaMap(t, schema)
+	if _, ok := m["required"]; ok {
+		t.Errorf("partial schema must not have required, got %v", m["required"])
+	}
+
+	props := m["properties"].(map[string]interface{})
+	book := props["book"].(map[string]interface{})
+	bookProps, ok := book["properties"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected nested book properties, got %v", book)
+	}
+	if _, ok := bookProps["title"]; !ok {
+		t.Error("expected title in nested book properties")
+	}
+	if _, ok := book["required"]; ok {
+		t.Error("nested partial schema must not have required")
+	}
+
+	mask := props["updateMask"].(map[string]interface{})
+	if mask["type"] != "string" {
+		t.Errorf("expected FieldMask rendered as string, got %v", mask["type"])
+	}
+	if mask["pattern"] != "^(?:title)(?:,(?:title))*$" {
+		t.Errorf("unexpected update_mask pattern %v", mask["pattern"])
+	}
+}
+
+func TestPartial_NestedPathsAndRecursion(t *testing.T) {
+	required := fieldOpts(ext(jsonschemapb.E_Required, true))
+	fd := testFile(t,
+		testMessage("Author", nil,
+			testField("display_name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, required),
+			testMessageField("mentor", 2, ".test.Author", nil),
+		),
+		testMessage("Book", nil,
+			testField("title", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, required),
+			testMessageField("author", 2, ".test.Author", nil),
+			repeated(testMessageField("co_authors", 3, ".test.Author", nil)),
+			testField("secret", 4, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(ext(jsonschemapb.E_Hidden, true))),
+			testMessageField("publish_time", 5, ".google.protobuf.Timestamp", nil),
+		),
+		testMessage("UpdateBookRequest", nil,
+			testMessageField("book", 1, ".test.Book", required),
+			testMessageField("update_mask", 2, ".google.protobuf.FieldMask", nil),
+		),
+	)
+
+	g := NewGenerator()
+	g.SetPartial(true)
+	schema, err := g.GenerateSchema(fd.Messages().ByName("UpdateBookRequest"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	m := mustSchemaMap(t, schema)
+	props := m["properties"].(map[string]interface{})
+
+	book := props["book"].(map[string]interface{})["properties"].(map[string]interface{})
+	author := book["author"].(map[string]interface{})
+	authorProps := author["properties"].(map[string]interface{})
+	mentor := authorProps["mentor"].(map[string]interface{})
+	if _, ok := mentor["properties"]; ok {
+		t.Error("recursive Author.mentor must stay a bare object")
+	}
+	coAuthors := book["coAuthors"].(map[string]interface{})
+	items := coAuthors["items"].(map[string]interface{})
+	if _, ok := items["properties"]; !ok {
+		t.Error("repeated nested message items must be expanded")
+	}
+	if _, ok := book["publishTime"].(map[string]interface{})["oneOf"]; !ok {
+		t.Error("well-known Timestamp must keep its own schema")
+	}
+
+	pattern := props["updateMask"].(map[string]interface{})["pattern"].(string)
+	re := regexp.MustCompile(pattern)
+	for _, valid := range []string{"title", "author.displayName,title", "author.mentor", "coAuthors", "publishTime"} {
+		if !re.MatchString(valid) {
+			t.Errorf("expected mask %q to match %s", valid, pattern)
+		}
+	}
+	for _, invalid := range []string{"secret", "author.mentor.displayName", "title,", "isbn"} {
+		if re.MatchString(invalid) {
+			t.Errorf("expected mask %q to be rejected by %s", invalid, pattern)
+		}
+	}
+}
+
+func TestPartial_OrderedSchema(t *testing.T) {
+	fd := testFile(t,
+		testMessage("Book", msgOpts(ext(jsonschemapb.E_Partial, true)),
+			testField("title", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(ext(jsonschemapb.E_Required, true))),
+		),
+	)
+
+	ordered, err := NewGeneratorWithOptions(true).GenerateOrderedSchema(fd.Messages().ByName("Book"))
+	if err != nil {
+		t.Fatalf("GenerateOrderedSchema failed: %v", err)
+	}
+	if len(ordered.Required) != 0 {
+		t.Errorf("partial ordered schema must not have required, got %v", ordered.Required)
+	}
+	data, err := json.Marshal(ordered)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	if string(data) != `{"type":"object","title":"Book","properties":{"title":{"type":"string"}}}` {
+		t.Errorf("unexpected ordered partial schema %s", data)
+	}
+}
+
+func TestPartial_DisabledKeepsRequired(t *testing.T) {
+	fd := testFile(t,
+		testMessage("Book", nil,
+			testField("title", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(ext(jsonschemapb.E_Required, true))),
+			testMessageField("update_mask", 2, ".google.protobuf.FieldMask", nil),
+		),
+	)
+
+	schema, err := NewGenerator().GenerateSchema(fd.Messages().ByName("Book"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	if _, ok := schema["required"]; !ok {
+		t.Error("non-partial schema must keep required")
+	}
+}
+
+func TestPartial_NonObjectItems(t *testing.T) {
+	fd := testFile(t,
+		testMessage("Book", nil, testField("title", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil)),
+		testMessage("UpdateShelfRequest", msgOpts(ext(jsonschemapb.E_Partial, true)),
+			repeated(testMessageField("books", 1, ".test.Book", fieldOpts(ext(jsonschemapb.E_SchemaJson, `{"items": true}`)))),
+		),
+	)
+
+	schema, err := NewGenerator().GenerateSchema(fd.Messages().ByName("UpdateShelfRequest"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	books := mustSchemaMap(t, schema)["properties"].(map[string]interface{})["books"].(map[string]interface{})
+	if books["items"] != true {
+		t.Errorf("expected non-object items to be kept, got %v", books["items"])
+	}
+}
@@ -0,0 +1,42 @@
+package jsonschema
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Extensions of protoc-gen-validate (github.com/envoyproxy/protoc-gen-validate),
+// resolved by name like the protovalidate ones. Its FieldRules share their
+// rule names with protovalidate, so both are translated by translateFieldRules.
+const (
+	pgvFieldExt    protoreflect.FullName = "validate.rules"
+	pgvDisabledExt protoreflect.FullName = "validate.disabled"
+	pgvIgnoredExt  protoreflect.FullName = "validate.ignored"
+)
+
+// pgvFieldRules returns the validate.rules of field, or nil
+func pgvFieldRules(field protoreflect.FieldDescriptor) (protoreflect.Message, error) {
+	rules, err := extensionMessage(field.ParentFile(), field.Options(), pgvFieldExt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid %s option: %w", field.FullName(), pgvFieldExt, err)
+	}
+	if rules == nil || isPGVDisabled(field.ContainingMessage()) {
+		return nil, nil
+	}
+	return rules, nil
+}
+
+// isPGVDisabled reports whether PGV rules of md's fields are switched off by
+// the validate.disabled or validate.ignored message options
+func isPGVDisabled(md protoreflect.MessageDescriptor) bool {
+	if md == nil {
+		return false
+	}
+	for _, name := range []protoreflect.FullName{pgvDisabledExt, pgvIgnoredExt} {
+		if disabled, err := extensionBool(md.ParentFile(), md.Options(), name); err == nil && disabled {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// pgvFile is a subset of protoc-gen-validate's validate/validate.proto with
+// the upstream field names and numbers
+var pgvFile = mustFile(&descriptorpb.FileDescriptorProto{
+	Name:       proto.String("validate/validate.proto"),
+	Package:    proto.String("validate"),
+	Syntax:     proto.String("proto2"),
+	Dependency: []string{"google/protobuf/descriptor.proto"},
+	MessageType: []*descriptorpb.DescriptorProto{
+		ruleMessage("FieldRules", []string{"type"},
+			ruleField("message", 17, tMessage, ".validate.MessageRules", nil),
+			ruleField("uint32", 5, tMessage, ".validate.UInt32Rules", proto.Int32(0)),
+			ruleField("string", 14, tMessage, ".validate.StringRules", proto.Int32(0)),
+			ruleField("repeated", 18, tMessage, ".validate.RepeatedRules", proto.Int32(0)),
+		),
+		ruleMessage("MessageRules", nil,
+			ruleField("skip", 1, tBool, "", nil),
+			ruleField("required", 2, tBool, "", nil),
+		),
+		ruleMessage("UInt32Rules", nil,
+			ruleField("const", 1, tUint32, "", nil),
+			ruleField("lt", 2, tUint32, "", nil),
+			ruleField("lte", 3, tUint32, "", nil),
+			ruleField("gt", 4, tUint32, "", nil),
+			ruleField("gte", 5, tUint32, "", nil),
+			ruleList(ruleField("in", 6, tUint32, "", nil)),
+			ruleList(ruleField("not_in", 7, tUint32, "", nil)),
+		),
+		ruleMessage("StringRules", []string{"well_known"},
+			ruleField("const", 1, tString, "", nil),
+			ruleField("min_len", 2, tUint64, "", nil),
+			ruleField("max_len", 3, tUint64, "", nil),
+			ruleField("pattern", 6, tString, "", nil),
+			ruleField("len", 19, tUint64, "", nil),
+			ruleField("ignore_empty", 26, tBool, "", nil),
+			ruleField("email", 12, tBool, "", proto.Int32(0)),
+			ruleField("uri", 17, tBool, "", proto.Int32(0)),
+			ruleField("uuid", 22, tBool, "", proto.Int32(0)),
+		),
+		ruleMessage("RepeatedRules", nil,
+			ruleField("min_items", 1, tUint64, "", nil),
+			ruleField("max_items", 2, tUint64, "", nil),
+			ruleField("unique", 3, tBool, "", nil),
+			ruleField("items", 4, tMessage, ".validate.FieldRules", nil),
+		),
+	},
+	Extension: []*descriptorpb.FieldDescriptorProto{
+		ruleExtension("disabled", 1071, tBool, "", ".google.protobuf.MessageOptions"),
+		ruleExtension("ignored", 1072, tBool, "", ".google.protobuf.MessageOptions"),
+		ruleExtension("rules", 1071, tMessage, ".validate.FieldRules", ".google.protobuf.FieldOptions"),
+	},
+})
+
+// pgvRules returns FieldOptions carrying validate.rules from protojson text
+func pgvRules(t *testing.T, text string) *descriptorpb.FieldOptions {
+	t.Helper()
+	msg := dynamicpb.NewMessage(pgvFile.Messages().ByName("FieldRules"))
+	if err := protojson.Unmarshal([]byte(text), msg); err != nil {
+		t.Fatalf("invalid FieldRules %s: %v", text, err)
+	}
+	return withUnknownMessage(t, &descriptorpb.FieldOptions{}, 1071, msg)
+}
+
+func pgvAccountFile(t *testing.T, opts *descriptorpb.MessageOptions) protoreflect.FileDescriptor {
+	t.Helper()
+	return fileImporting(t, []protoreflect.FileDescriptor{pgvFile}, nil,
+		testMessage("Account", opts,
+			testField("email", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, pgvRules(t, `{"string": {"email": true, "max_len": 254}}`)),
+			testField("code", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, pgvRules(t, `{"string": {"len": 6, "pattern": "^[0-9]+$", "ignore_empty": true}}`)),
+			testField("level", 3, descriptorpb.FieldDescriptorProto_TYPE_UINT32, pgvRules(t, `{"uint32": {"gt": 0, "lte": 10, "not_in": [7]}}`)),
+			repeated(testField("ids", 4, descriptorpb.FieldDescriptorProto_TYPE_STRING, pgvRules(t, `{"repeated": {"min_items": 1, "unique": true, "items": {"string": {"uuid": true}}}}`))),
+			testMessageField("owner", 5, ".test.Account", pgvRules(t, `{"message": {"required": true}}`)),
+		),
+	)
+}
+
+func TestPGV_OptIn(t *testing.T) {
+	fd := pgvAccountFile(t, nil)
+
+	schema, err := NewGenerator().GenerateSchema(fd.Messages().ByName("Account"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	if _, ok := schema["required"]; ok {
+		t.Error("PGV rules must be ignored unless enabled")
+	}
+	email := schema["properties"].(map[string]interface{})["email"].(Schema)
+	if len(email) != 1 {
+		t.Errorf("PGV rules must be ignored unless enabled, got %v", email)
+	}
+}
+
+func TestPGV_FieldRules(t *testing.T) {
+	fd := pgvAccountFile(t, nil)
+	g := NewGenerator()
+	g.SetPGV(true)
+
+	schema, err := g.GenerateSchema(fd.Messages().ByName("Account"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	m := mustSchemaMap(t, schema)
+	props := m["properties"].(map[string]interface{})
+
+	assertKeywords(t, props["email"], `{"format":"email","maxLength":254,"type":"string"}`)
+	assertKeywords(t, props["code"], `{"description":"Validation: string.ignore_empty","maxLength":6,"minLength":6,"pattern":"^[0-9]+$","type":"string"}`)
+	assertKeywords(t, props["level"], `{"exclusiveMinimum":0,"maximum":10,"not":{"enum":[7]},"type":"integer"}`)
+	assertKeywords(t, props["ids"], `{"items":{"format":"uuid","type":"string"},"minItems":1,"type":"array","uniqueItems":true}`)
+	if required, _ := json.Marshal(m["required"]); string(required) != `["owner"]` {
+		t.Errorf("expected message.required field to be required, got %s", required)
+	}
+}
+
+func TestPGV_ProtovalidateTakesPrecedence(t *testing.T) {
+	opts := withUnknownRules(t, pgvRules(t, `{"string": {"email": true}}`), rules(t, "FieldRules", `{"string": {"uri": true}}`))
+	fd := fileImporting(t, []protoreflect.FileDescriptor{validateFile, pgvFile}, nil,
+		testMessage("Link", nil, testField("target", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, opts)),
+	)
+	g := NewGenerator()
+	g.SetPGV(true)
+	schema, err := g.GenerateSchema(fd.Messages().ByName("Link"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	assertKeywords(t, schema["properties"].(map[string]interface{})["target"], `{"format":"uri","type":"string"}`)
+}
+
+func TestPGV_DisabledMessage(t *testing.T) {
+	for _, number := range []protowire.Number{1071, 1072} {
+		opts := &descriptorpb.MessageOptions{}
+		raw := protowire.AppendTag(nil, number, protowire.VarintType)
+		opts.ProtoReflect().SetUnknown(protowire.AppendVarint(raw, 1))
+		fd := pgvAccountFile(t, opts)
+
+		g := NewGenerator()
+		g.SetPGV(true)
+		schema, err := g.GenerateSchema(fd.Messages().ByName("Account"))
+		if err != nil {
+			t.Fatalf("GenerateSchema failed: %v", err)
+		}
+		if _, ok := schema["required"]; ok {
+			t.Errorf("option %d must disable PGV rules", number)
+		}
+		if email := schema["properties"].(map[string]interface{})["email"].(Schema); len(email) != 1 {
+			t.Errorf("option %d must disable PGV rules, got %v", number, email)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package jsonschema
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Profile rewrites generated schemas into the subset of JSON Schema a
+// particular consumer accepts
+type Profile string
+
+// Supported profiles
+const (
+	// ProfileOpenAIStrict targets OpenAI structured outputs and strict
+	// function calling
+	ProfileOpenAIStrict Profile = "openai_strict"
+	// ProfileGemini targets Gemini function declarations
+	ProfileGemini Profile = "gemini"
+	// ProfileMCPElicitation targets the requestedSchema of MCP elicitation
+	// requests, which only accepts flat forms
+	ProfileMCPElicitation Profile = "mcp_elicitation"
+)
+
+// WarningHandler receives what a profile had to drop from the schema of md
+// to fit its consumer
+type WarningHandler func(md protoreflect.MessageDescriptor, warning string)
+
+// profileRefPrefix marks message references that profiles inline again
+const profileRefPrefix = "#/$defs/"
+
+// SetProfile sets the profile applied to every generated schema; an empty
+// profile leaves schemas as generated
+func (g *Generator) SetProfile(profile Profile) {
+	g.profile = profile
+}
+
+// Profile returns the profile applied to generated schemas
+func (g *Generator) Profile() Profile {
+	return g.profile
+}
+
+// SetWarningHandler sets the handler receiving profile warnings; without
+// one they are discarded
+func (g *Generator) SetWarningHandler(handler WarningHandler) {
+	g.warningHandler = handler
+}
+
+// IsValid reports whether p is one of the supported profiles
+func (p Profile) IsValid() bool {
+	return p == ProfileOpenAIStrict || p == ProfileGemini || p == ProfileMCPElicitation
+}
+
+// applyProfile rewrites the schema of md for the generator's profile. Message
+// fields, generated as references, are first expanded inline since none of
+// the profiles' consumers resolve them; recursive messages are an error.
+func (g *Generator) applyProfile(md protoreflect.MessageDescriptor, schema Schema, order []string) (Schema, error) {
+	if g.profile == "" {
+		return schema, nil
+	}
+
+	if g.profile == ProfileMCPElicitation {
+		// checked before nested messages are inlined, so errors name the field
+		if err := g.checkElicitationFields(md); err != nil {
+			return nil, fmt.Errorf("%s: %s profile: %w", md.FullName(), g.profile, err)
+		}
+	}
+
+	nested := *g
+	nested.profile = ""
+	nested.dialect = ""
+	index := map[string]protoreflect.MessageDescriptor{}
+	indexMessages(md, index)
+	if err := nested.inlineRefs(schema, index, []string{string(md.FullName())}); err != nil {
+		return nil, fmt.Errorf("%s: %s profile: %w", md.FullName(), g.profile, err)
+	}
+
+	warn := func(warning string) {
+		if g.warningHandler != nil {
+			g.warningHandler(md, warning)
+		}
+	}
+	var err error
+	switch g.profile {
+	case ProfileOpenAIStrict:
+		err = strictSchema(schema, "#", order)
+	case ProfileGemini:
+		geminiSchema(schema, "#", warn)
+	case ProfileMCPElicitation:
+		schema, err = elicitationSchema(schema, warn)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s profile: %w", md.FullName(), g.profile, err)
+	}
+	return schema, nil
+}
+
+// indexMessages adds the message types of the fields of md, and of their
+// fields in turn, to index by full name
+func indexMessages(md protoreflect.MessageDescriptor, index map[string]protoreflect.MessageDescriptor) {
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.IsMap() {
+			field = field.MapValue()
+		}
+		ref := field.Message()
+		if ref == nil {
+			continue
+		}
+		if _, seen := index[string(ref.FullName())]; !seen {
+			index[string(ref.FullName())] = ref
+			indexMessages(ref, index)
+		}
+	}
+}
+
+// inlineRefs replaces every message reference in schema with the schema of
+// the message it names. Keywords set next to the reference, such as a field
+// description, win over the message's own. stack lists the messages being
+// expanded, outermost first.
+func (g *Generator) inlineRefs(schema map[string]interface{}, index map[string]protoreflect.MessageDescriptor, stack []string) error {
+	if ref, ok := schema["$ref"].(string); ok && strings.HasPrefix(ref, g.refPrefix) {
+		name := strings.TrimPrefix(ref, g.refPrefix)
+		for i, expanding := range stack {
+			if expanding == name {
+				return fmt.Errorf("recursive message %s cannot be inlined: %s", name, strings.Join(append(stack[i:len(stack):len(stack)], name), " -> "))
+			}
+		}
+		md, ok := index[name]
+		if !ok {
+			return fmt.Errorf("unknown message %s", name)
+		}
+		message, _, err := g.buildSchema(md)
+		if err != nil {
+			return err
+		}
+		if message == nil {
+			message = Schema{"type": "object"}
+		}
+		delete(schema, "$ref")
+		for key, value := range message {
+			if _, ok := schema[key]; !ok {
+				schema[key] = value
+			}
+		}
+		stack = append(stack[:len(stack):len(stack)], name)
+	}
+
+	var err error
+	forEachSubschema(schema, func(sub map[string]interface{}) {
+		if err == nil {
+			err = g.inlineRefs(sub, index, stack)
+		}
+	})
+	return err
+}
@@ -0,0 +1,566 @@
+package jsonschema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Extensions of buf protovalidate (buf.build/bufbuild/protovalidate). They are
+// resolved by name rather than through generated code, so rules are read
+// whether or not the protovalidate Go package is linked into the binary.
+const (
+	protovalidateFieldExt   protoreflect.FullName = "buf.validate.field"
+	protovalidateMessageExt protoreflect.FullName = "buf.validate.message"
+)
+
+// protovalidateStringFormats maps protovalidate well-known string rules to
+// JSON Schema formats
+var protovalidateStringFormats = map[protoreflect.Name]string{
+	"email":    "email",
+	"hostname": "hostname",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+	"uri":      "uri",
+	"uri_ref":  "uri-reference",
+	"uuid":     "uuid",
+}
+
+// protovalidateFieldRules returns the buf.validate.field rules of field, or nil
+func protovalidateFieldRules(field protoreflect.FieldDescriptor) (protoreflect.Message, error) {
+	rules, err := extensionMessage(field.ParentFile(), field.Options(), protovalidateFieldExt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid %s option: %w", field.FullName(), protovalidateFieldExt, err)
+	}
+	if rules == nil || isIgnoredRules(rules) || isDisabledMessage(field.ContainingMessage()) {
+		return nil, nil
+	}
+	return rules, nil
+}
+
+// protovalidateMessageRules returns the buf.validate.message rules of md, or nil
+func protovalidateMessageRules(md protoreflect.MessageDescriptor) (protoreflect.Message, error) {
+	rules, err := extensionMessage(md.ParentFile(), md.Options(), protovalidateMessageExt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid %s option: %w", md.FullName(), protovalidateMessageExt, err)
+	}
+	return rules, nil
+}
+
+// isIgnoredRules reports whether field rules are switched off with IGNORE_ALWAYS
+func isIgnoredRules(rules protoreflect.Message) bool {
+	value, ok := ruleValue(rules, "ignore")
+	if !ok {
+		return false
+	}
+	enumValue := rules.Descriptor().Fields().ByName("ignore").Enum().Values().ByNumber(value.Enum())
+	return enumValue != nil && enumValue.Name() == "IGNORE_ALWAYS"
+}
+
+// isDisabledMessage reports whether the protovalidate rules of md's fields are
+// disabled by the message-level disabled rule
+func isDisabledMessage(md protoreflect.MessageDescriptor) bool {
+	if md == nil {
+		return false
+	}
+	rules, err := protovalidateMessageRules(md)
+	if err != nil || rules == nil {
+		return false
+	}
+	value, ok := ruleValue(rules, "disabled")
+	return ok && value.Bool()
+}
+
+// extensionMessage returns the message-typed extension name set on opts, or
+// nil if it is not set
+func extensionMessage(file protoreflect.FileDescriptor, opts proto.Message, name protoreflect.FullName) (protoreflect.Message, error) {
+	value, ok, err := extensionValue(file, opts, name)
+	if err != nil || !ok {
+		return nil, err
+	}
+	msg, _ := value.Interface().(protoreflect.Message)
+	return msg, nil
+}
+
+// extensionBool reports whether the bool extension name is set to true on opts
+func extensionBool(file protoreflect.FileDescriptor, opts proto.Message, name protoreflect.FullName) (bool, error) {
+	value, ok, err := extensionValue(file, opts, name)
+	if err != nil || !ok {
+		return false, err
+	}
+	b, _ := value.Interface().(bool)
+	return b, nil
+}
+
+// extensionValue returns the value of the extension name set on opts. A
+// linked extension is read directly; otherwise the extension is looked up in
+// the imports of file and decoded from the unknown fields of opts.
+func extensionValue(file protoreflect.FileDescriptor, opts proto.Message, name protoreflect.FullName) (protoreflect.Value, bool, error) {
+	if opts == nil {
+		return protoreflect.Value{}, false, nil
+	}
+
+	var (
+		found protoreflect.Value
+		ok    bool
+	)
+	opts.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.IsExtension() && fd.FullName() == name {
+			found, ok = v, true
+			return false
+		}
+		return true
+	})
+	if ok {
+		return found, true, nil
+	}
+
+	unknown := opts.ProtoReflect().GetUnknown()
+	if len(unknown) == 0 {
+		return protoreflect.Value{}, false, nil
+	}
+	xd := findExtension(file, name, map[string]bool{})
+	if xd == nil {
+		return protoreflect.Value{}, false, nil
+	}
+
+	xt := dynamicpb.NewExtensionType(xd)
+	decoded := opts.ProtoReflect().New()
+	unmarshal := proto.UnmarshalOptions{Resolver: extensionResolver{xt}}
+	if err := unmarshal.Unmarshal(unknown, decoded.Interface()); err != nil {
+		return protoreflect.Value{}, false, err
+	}
+	if !decoded.Has(xt.TypeDescriptor()) {
+		return protoreflect.Value{}, false, nil
+	}
+	return decoded.Get(xt.TypeDescriptor()), true, nil
+}
+
+// extensionResolver resolves a single extension type when decoding options
+type extensionResolver struct {
+	xt protoreflect.ExtensionType
+}
+
+func (r extensionResolver) FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error) {
+	if field == r.xt.TypeDescriptor().FullName() {
+		return r.xt, nil
+	}
+	return nil, protoregistry.NotFound
+}
+
+func (r extensionResolver) FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error) {
+	xd := r.xt.TypeDescriptor()
+	if message == xd.ContainingMessage().FullName() && field == xd.Number() {
+		return r.xt, nil
+	}
+	return nil, protoregistry.NotFound
+}
+
+// findExtension looks up the extension name in file and its transitive imports
+func findExtension(file protoreflect.FileDescriptor, name protoreflect.FullName, visited map[string]bool) protoreflect.ExtensionDescriptor {
+	if file == nil || visited[file.Path()] {
+		return nil
+	}
+	visited[file.Path()] = true
+
+	if file.Package() == name.Parent() {
+		if xd := file.Extensions().ByName(name.Name()); xd != nil {
+			return xd
+		}
+	}
+	imports := file.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		if xd := findExtension(imports.Get(i).FileDescriptor, name, visited); xd != nil {
+			return xd
+		}
+	}
+	return nil
+}
+
+// applyValidationRules translates the validation rules of field into JSON
+// Schema keywords on schema: buf.validate.field rules, or else PGV
+// validate.rules when enabled. Rules without a JSON Schema equivalent,
+// including CEL expressions, are returned as notes for the description.
+func (g *Generator) applyValidationRules(field protoreflect.FieldDescriptor, schema Schema) ([]string, error) {
+	rules, err := g.fieldValidationRules(field)
+	if err != nil || rules == nil {
+		return nil, err
+	}
+	return translateFieldRules(field, rules, schema), nil
+}
+
+// fieldValidationRules returns the protovalidate rules of field, or else its
+// PGV rules when enabled, or nil
+func (g *Generator) fieldValidationRules(field protoreflect.FieldDescriptor) (protoreflect.Message, error) {
+	rules, err := protovalidateFieldRules(field)
+	if err != nil || rules != nil || !g.pgv {
+		return rules, err
+	}
+	return pgvFieldRules(field)
+}
+
+// isValidationRequired reports whether the validation rules of field mark it
+// as required: the protovalidate required rule, or PGV message.required
+func (g *Generator) isValidationRequired(field protoreflect.FieldDescriptor) bool {
+	rules, err := g.fieldValidationRules(field)
+	if err != nil || rules == nil {
+		return false
+	}
+	if value, ok := ruleValue(rules, "required"); ok {
+		return value.Bool()
+	}
+	if value, ok := ruleValue(rules, "message"); ok {
+		required, ok := ruleValue(value.Message(), "required")
+		return ok && required.Bool()
+	}
+	return false
+}
+
+// translateFieldRules applies a FieldRules message to schema
+func translateFieldRules(field protoreflect.FieldDescriptor, rules protoreflect.Message, schema Schema) []string {
+	notes := celNotes(rules)
+
+	typeOneof := rules.Descriptor().Oneofs().ByName("type")
+	if typeOneof == nil {
+		return notes
+	}
+	typeField := rules.WhichOneof(typeOneof)
+	if typeField == nil {
+		return notes
+	}
+	typeRules := rules.Get(typeField).Message()
+	prefix := string(typeField.Name())
+
+	switch typeField.Name() {
+	case "string":
+		notes = append(notes, translateStringRules(typeRules, schema)...)
+	case "bool":
+		if value, ok := ruleValue(typeRules, "const"); ok {
+			schema["const"] = value.Bool()
+		}
+	case "enum":
+		notes = append(notes, translateEnumRules(field.Enum(), typeRules, schema)...)
+	case "repeated":
+		notes = append(notes, translateRepeatedRules(field, typeRules, schema)...)
+	case "float", "double", "int32", "int64", "uint32", "uint64",
+		"sint32", "sint64", "fixed32", "fixed64", "sfixed32", "sfixed64":
+		notes = append(notes, translateNumericRules(prefix, typeRules, schema)...)
+	default:
+		notes = append(notes, untranslatedRules(prefix, typeRules, nil)...)
+	}
+	return notes
+}
+
+// translateStringRules applies StringRules to a string schema. A prefix is
+// expressed as a pattern unless the rules carry a pattern of their own.
+func translateStringRules(rules protoreflect.Message, schema Schema) []string {
+	handled := map[protoreflect.Name]bool{
+		"const": true, "len": true, "min_len": true, "max_len": true,
+		"pattern": true, "in": true, "not_in": true,
+	}
+
+	if value, ok := ruleValue(rules, "const"); ok {
+		schema["const"] = value.String()
+	}
+	if value, ok := ruleValue(rules, "len"); ok {
+		schema["minLength"] = int64(value.Uint())
+		schema["maxLength"] = int64(value.Uint())
+	}
+	if value, ok := ruleValue(rules, "min_len"); ok {
+		schema["minLength"] = int64(value.Uint())
+	}
+	if value, ok := ruleValue(rules, "max_len"); ok {
+		schema["maxLength"] = int64(value.Uint())
+	}
+	if value, ok := ruleValue(rules, "pattern"); ok {
+		schema["pattern"] = value.String()
+	} else if value, ok := ruleValue(rules, "prefix"); ok {
+		schema["pattern"] = "^" + regexp.QuoteMeta(value.String())
+		handled["prefix"] = true
+	}
+	if values := listValues(rules, "in"); values != nil {
+		schema["enum"] = values
+	}
+	if values := listValues(rules, "not_in"); values != nil {
+		schema["not"] = Schema{"enum": values}
+	}
+	for name, format := range protovalidateStringFormats {
+		if value, ok := ruleValue(rules, name); ok && value.Bool() {
+			schema["format"] = format
+			handled[name] = true
+		}
+	}
+	return untranslatedRules("string", rules, handled)
+}
+
+// translateEnumRules applies EnumRules to an enum schema, narrowing its list
+// of value names
+func translateEnumRules(ed protoreflect.EnumDescriptor, rules protoreflect.Message, schema Schema) []string {
+	valueName := func(v protoreflect.Value) string {
+		if ev := ed.Values().ByNumber(protoreflect.EnumNumber(v.Int())); ev != nil {
+			return string(ev.Name())
+		}
+		return fmt.Sprint(v.Int())
+	}
+
+	if value, ok := ruleValue(rules, "const"); ok {
+		schema["const"] = valueName(value)
+	}
+	if value, ok := ruleValue(rules, "in"); ok {
+		// Values already left out of the enum (e.g. for another audience)
+		// stay out
+		allowed := map[string]bool{}
+		current, narrowing := schema["enum"].([]string)
+		for _, name := range current {
+			allowed[name] = true
+		}
+		names := []string{}
+		list := value.List()
+		for i := 0; i < list.Len(); i++ {
+			if name := valueName(list.Get(i)); !narrowing || allowed[name] {
+				names = append(names, name)
+			}
+		}
+		schema["enum"] = names
+	}
+	if value, ok := ruleValue(rules, "not_in"); ok {
+		excluded := map[string]bool{}
+		list := value.List()
+		for i := 0; i < list.Len(); i++ {
+			excluded[valueName(list.Get(i))] = true
+		}
+		if names, ok := schema["enum"].([]string); ok {
+			kept := []string{}
+			for _, name := range names {
+				if !excluded[name] {
+					kept = append(kept, name)
+				}
+			}
+			schema["enum"] = kept
+		}
+	}
+	return untranslatedRules("enum", rules, map[protoreflect.Name]bool{
+		"const": true, "in": true, "not_in": true, "defined_only": true,
+	})
+}
+
+// translateRepeatedRules applies RepeatedRules to an array schema, and the
+// per-item rules to its items
+func translateRepeatedRules(field protoreflect.FieldDescriptor, rules protoreflect.Message, schema Schema) []string {
+	var notes []string
+	if value, ok := ruleValue(rules, "min_items"); ok {
+		schema["minItems"] = int64(value.Uint())
+	}
+	if value, ok := ruleValue(rules, "max_items"); ok {
+		schema["maxItems"] = int64(value.Uint())
+	}
+	if value, ok := ruleValue(rules, "unique"); ok && value.Bool() {
+		schema["uniqueItems"] = true
+	}
+	if value, ok := ruleValue(rules, "items"); ok {
+		if items, isObject := asObject(schema["items"]); isObject && !field.IsMap() {
+			for _, note := range translateFieldRules(field, value.Message(), items) {
+				notes = append(notes, "items: "+note)
+			}
+		}
+	}
+	return append(notes, untranslatedRules("repeated", rules, map[protoreflect.Name]bool{
+		"min_items": true, "max_items": true, "unique": true, "items": true,
+	})...)
+}
+
+// translateNumericRules applies the rules of any numeric type to a number or
+// integer schema. Exclusive ranges (lower bound above the upper bound) have no
+// single-schema equivalent and are left as notes.
+func translateNumericRules(prefix string, rules protoreflect.Message, schema Schema) []string {
+	handled := map[protoreflect.Name]bool{"const": true, "in": true, "not_in": true, "finite": true}
+	if value, ok := ruleValue(rules, "const"); ok {
+		schema["const"] = numericValue(value)
+	}
+	if values := listValues(rules, "in"); values != nil {
+		schema["enum"] = values
+	}
+	if values := listValues(rules, "not_in"); values != nil {
+		schema["not"] = Schema{"enum": values}
+	}
+
+	bounds := map[protoreflect.Name]string{
+		"lt": "exclusiveMaximum", "lte": "maximum",
+		"gt": "exclusiveMinimum", "gte": "minimum",
+	}
+	lower, hasLower := firstRule(rules, "gt", "gte")
+	upper, hasUpper := firstRule(rules, "lt", "lte")
+	if hasLower && hasUpper && numericValue(rules.Get(rules.Descriptor().Fields().ByName(lower))) >
+		numericValue(rules.Get(rules.Descriptor().Fields().ByName(upper))) {
+		return untranslatedRules(prefix, rules, handled)
+	}
+	for name, keyword := range bounds {
+		if value, ok := ruleValue(rules, name); ok {
+			schema[keyword] = numericValue(value)
+			handled[name] = true
+		}
+	}
+	return untranslatedRules(prefix, rules, handled)
+}
+
+// celNotes describes the custom CEL rules of a rules message, preferring each
+// rule's message over its expression
+func celNotes(rules protoreflect.Message) []string {
+	var notes []string
+	if value, ok := ruleValue(rules, "cel"); ok {
+		list := value.List()
+		for i := 0; i < list.Len(); i++ {
+			rule := list.Get(i).Message()
+			note := ruleString(rule, "message")
+			if note == "" {
+				note = ruleString(rule, "expression")
+			}
+			if note != "" {
+				notes = append(notes, note)
+			}
+		}
+	}
+	if value, ok := ruleValue(rules, "cel_expression"); ok {
+		list := value.List()
+		for i := 0; i < list.Len(); i++ {
+			notes = append(notes, list.Get(i).String())
+		}
+	}
+	return notes
+}
+
+// untranslatedRules describes every set rule of a type rules message that is
+// not in handled, e.g. `string.suffix = ".pdf"`
+func untranslatedRules(prefix string, rules protoreflect.Message, handled map[protoreflect.Name]bool) []string {
+	var notes []string
+	fields := rules.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if handled[fd.Name()] || !rules.Has(fd) {
+			continue
+		}
+		if fd.Name() == "cel" || fd.Name() == "example" {
+			continue
+		}
+		value := rules.Get(fd)
+		if fd.Kind() == protoreflect.BoolKind && !fd.IsList() {
+			if value.Bool() {
+				notes = append(notes, prefix+"."+string(fd.Name()))
+			}
+			continue
+		}
+		notes = append(notes, fmt.Sprintf("%s.%s = %s", prefix, fd.Name(), formatRuleValue(fd, value)))
+	}
+	return notes
+}
+
+// formatRuleValue renders a rule value for a description note
+func formatRuleValue(fd protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	if fd.IsList() {
+		list := value.List()
+		items := make([]string, list.Len())
+		for i := range items {
+			items[i] = formatScalar(fd, list.Get(i))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+	return formatScalar(fd, value)
+}
+
+// formatScalar renders a single rule value for a description note
+func formatScalar(fd protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return fmt.Sprintf("%q", value.String())
+	case protoreflect.BytesKind:
+		return fmt.Sprintf("%q", value.Bytes())
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(value.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return fmt.Sprint(value.Enum())
+	case protoreflect.MessageKind:
+		return fmt.Sprint(value.Message().Interface())
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+// ruleValue returns the value of the rule field name if it is set
+func ruleValue(rules protoreflect.Message, name protoreflect.Name) (protoreflect.Value, bool) {
+	fd := rules.Descriptor().Fields().ByName(name)
+	if fd == nil || !rules.Has(fd) {
+		return protoreflect.Value{}, false
+	}
+	return rules.Get(fd), true
+}
+
+// ruleString returns the string rule field name, or "" if it is unset
+func ruleString(rules protoreflect.Message, name protoreflect.Name) string {
+	if value, ok := ruleValue(rules, name); ok {
+		return value.String()
+	}
+	return ""
+}
+
+// firstRule returns the first of names set on rules
+func firstRule(rules protoreflect.Message, names ...protoreflect.Name) (protoreflect.Name, bool) {
+	for _, name := range names {
+		if _, ok := ruleValue(rules, name); ok {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// listValues returns a repeated string or numeric rule as JSON values, or nil
+// if it is unset
+func listValues(rules protoreflect.Message, name protoreflect.Name) []interface{} {
+	value, ok := ruleValue(rules, name)
+	if !ok {
+		return nil
+	}
+	fd := rules.Descriptor().Fields().ByName(name)
+	list := value.List()
+	values := make([]interface{}, list.Len())
+	for i := range values {
+		if fd.Kind() == protoreflect.StringKind {
+			values[i] = list.Get(i).String()
+		} else {
+			values[i] = numericValue(list.Get(i))
+		}
+	}
+	return values
+}
+
+// numericValue converts a numeric rule value to float64
+func numericValue(value protoreflect.Value) float64 {
+	switch v := value.Interface().(type) {
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint32:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
+
+// appendValidationNotes appends untranslated validation rules to the
+// description of schema
+func appendValidationNotes(schema Schema, notes []string) {
+	if len(notes) == 0 {
+		return
+	}
+	appendDescription(schema, "Validation: "+strings.Join(notes, "; "))
+}
@@ -0,0 +1,381 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+const (
+	tBool    = descriptorpb.FieldDescriptorProto_TYPE_BOOL
+	tString  = descriptorpb.FieldDescriptorProto_TYPE_STRING
+	tInt32   = descriptorpb.FieldDescriptorProto_TYPE_INT32
+	tUint32  = descriptorpb.FieldDescriptorProto_TYPE_UINT32
+	tUint64  = descriptorpb.FieldDescriptorProto_TYPE_UINT64
+	tDouble  = descriptorpb.FieldDescriptorProto_TYPE_DOUBLE
+	tMessage = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+	tEnum    = descriptorpb.FieldDescriptorProto_TYPE_ENUM
+)
+
+// ruleField builds an optional proto2 field of a synthetic rules descriptor;
+// typeName is fully qualified and oneof is the index of its oneof, if any
+func ruleField(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string, oneof *int32) *descriptorpb.FieldDescriptorProto {
+	f := &descriptorpb.FieldDescriptorProto{
+		Name:       proto.String(name),
+		Number:     proto.Int32(number),
+		Label:      descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:       typ.Enum(),
+		OneofIndex: oneof,
+	}
+	if typeName != "" {
+		f.TypeName = proto.String(typeName)
+	}
+	return f
+}
+
+// ruleList marks a synthetic rules field as repeated
+func ruleList(f *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+	f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	return f
+}
+
+// ruleMessage builds a synthetic rules message with the given oneofs
+func ruleMessage(name string, oneofs []string, fields ...*descriptorpb.FieldDescriptorProto) *descriptorpb.DescriptorProto {
+	m := &descriptorpb.DescriptorProto{Name: proto.String(name), Field: fields}
+	for _, o := range oneofs {
+		m.OneofDecl = append(m.OneofDecl, &descriptorpb.OneofDescriptorProto{Name: proto.String(o)})
+	}
+	return m
+}
+
+// ruleExtension builds an extension of a descriptor.proto options message
+func ruleExtension(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName, extendee string) *descriptorpb.FieldDescriptorProto {
+	f := ruleField(name, number, typ, typeName, nil)
+	f.Extendee = proto.String(extendee)
+	return f
+}
+
+// mustFile builds a file descriptor resolved against the global registry
+func mustFile(fdp *descriptorpb.FileDescriptorProto) protoreflect.FileDescriptor {
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if err != nil {
+		panic(err)
+	}
+	return fd
+}
+
+// validateFile is a subset of buf/validate/validate.proto with the upstream
+// field names and numbers, so tests exercise the same wire format.
+var validateFile = mustFile(&descriptorpb.FileDescriptorProto{
+	Name:       proto.String("buf/validate/validate.proto"),
+	Package:    proto.String("buf.validate"),
+	Syntax:     proto.String("proto2"),
+	Dependency: []string{"google/protobuf/descriptor.proto"},
+	EnumType: []*descriptorpb.EnumDescriptorProto{{
+		Name: proto.String("Ignore"),
+		Value: []*descriptorpb.EnumValueDescriptorProto{
+			{Name: proto.String("IGNORE_UNSPECIFIED"), Number: proto.Int32(0)},
+			{Name: proto.String("IGNORE_ALWAYS"), Number: proto.Int32(3)},
+		},
+	}},
+	MessageType: []*descriptorpb.DescriptorProto{
+		ruleMessage("Rule", nil,
+			ruleField("id", 1, tString, "", nil),
+			ruleField("message", 2, tString, "", nil),
+			ruleField("expression", 3, tString, "", nil),
+		),
+		ruleMessage("MessageRules", nil,
+			ruleField("disabled", 1, tBool, "", nil),
+			ruleList(ruleField("cel", 3, tMessage, ".buf.validate.Rule", nil)),
+		),
+		ruleMessage("FieldRules", []string{"type"},
+			ruleField("double", 2, tMessage, ".buf.validate.DoubleRules", proto.Int32(0)),
+			ruleField("int32", 3, tMessage, ".buf.validate.Int32Rules", proto.Int32(0)),
+			ruleField("string", 14, tMessage, ".buf.validate.StringRules", proto.Int32(0)),
+			ruleField("enum", 16, tMessage, ".buf.validate.EnumRules", proto.Int32(0)),
+			ruleField("repeated", 18, tMessage, ".buf.validate.RepeatedRules", proto.Int32(0)),
+			ruleList(ruleField("cel", 23, tMessage, ".buf.validate.Rule", nil)),
+			ruleField("required", 25, tBool, "", nil),
+			ruleField("ignore", 27, tEnum, ".buf.validate.Ignore", nil),
+		),
+		ruleMessage("DoubleRules", []string{"less_than", "greater_than"},
+			ruleField("const", 1, tDouble, "", nil),
+			ruleField("lt", 2, tDouble, "", proto.Int32(0)),
+			ruleField("lte", 3, tDouble, "", proto.Int32(0)),
+			ruleField("gt", 4, tDouble, "", proto.Int32(1)),
+			ruleField("gte", 5, tDouble, "", proto.Int32(1)),
+			ruleList(ruleField("in", 6, tDouble, "", nil)),
+			ruleList(ruleField("not_in", 7, tDouble, "", nil)),
+			ruleField("finite", 8, tBool, "", nil),
+		),
+		ruleMessage("Int32Rules", []string{"less_than", "greater_than"},
+			ruleField("const", 1, tInt32, "", nil),
+			ruleField("lt", 2, tInt32, "", proto.Int32(0)),
+			ruleField("lte", 3, tInt32, "", proto.Int32(0)),
+			ruleField("gt", 4, tInt32, "", proto.Int32(1)),
+			ruleField("gte", 5, tInt32, "", proto.Int32(1)),
+			ruleList(ruleField("in", 6, tInt32, "", nil)),
+			ruleList(ruleField("not_in", 7, tInt32, "", nil)),
+		),
+		ruleMessage("StringRules", []string{"well_known"},
+			ruleField("const", 1, tString, "", nil),
+			ruleField("min_len", 2, tUint64, "", nil),
+			ruleField("max_len", 3, tUint64, "", nil),
+			ruleField("pattern", 6, tString, "", nil),
+			ruleField("prefix", 7, tString, "", nil),
+			ruleField("suffix", 8, tString, "", nil),
+			ruleList(ruleField("in", 10, tString, "", nil)),
+			ruleList(ruleField("not_in", 11, tString, "", nil)),
+			ruleField("len", 19, tUint64, "", nil),
+			ruleField("email", 12, tBool, "", proto.Int32(0)),
+			ruleField("hostname", 13, tBool, "", proto.Int32(0)),
+			ruleField("uri", 17, tBool, "", proto.Int32(0)),
+			ruleField("uuid", 22, tBool, "", proto.Int32(0)),
+		),
+		ruleMessage("EnumRules", nil,
+			ruleField("const", 1, tInt32, "", nil),
+			ruleField("defined_only", 2, tBool, "", nil),
+			ruleList(ruleField("in", 3, tInt32, "", nil)),
+			ruleList(ruleField("not_in", 4, tInt32, "", nil)),
+		),
+		ruleMessage("RepeatedRules", nil,
+			ruleField("min_items", 1, tUint64, "", nil),
+			ruleField("max_items", 2, tUint64, "", nil),
+			ruleField("unique", 3, tBool, "", nil),
+			ruleField("items", 4, tMessage, ".buf.validate.FieldRules", nil),
+		),
+	},
+	Extension: []*descriptorpb.FieldDescriptorProto{
+		ruleExtension("message", 1159, tMessage, ".buf.validate.MessageRules", ".google.protobuf.MessageOptions"),
+		ruleExtension("field", 1159, tMessage, ".buf.validate.FieldRules", ".google.protobuf.FieldOptions"),
+	},
+})
+
+// rules builds a buf.validate message from its protojson text
+func rules(t *testing.T, name protoreflect.Name, text string) *dynamicpb.Message {
+	t.Helper()
+	msg := dynamicpb.NewMessage(validateFile.Messages().ByName(name))
+	if err := protojson.Unmarshal([]byte(text), msg); err != nil {
+		t.Fatalf("invalid %s %s: %v", name, text, err)
+	}
+	return msg
+}
+
+// withUnknownRules stores rules on opts as unknown extension field 1159, the
+// form options take when protovalidate is not linked into the binary
+func withUnknownRules[T proto.Message](t *testing.T, opts T, rules proto.Message) T {
+	t.Helper()
+	return withUnknownMessage(t, opts, 1159, rules)
+}
+
+// withUnknownMessage appends msg to opts as unknown field number
+func withUnknownMessage[T proto.Message](t *testing.T, opts T, number protowire.Number, msg proto.Message) T {
+	t.Helper()
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatalf("marshal %s: %v", msg.ProtoReflect().Descriptor().FullName(), err)
+	}
+	raw := protowire.AppendTag(opts.ProtoReflect().GetUnknown(), number, protowire.BytesType)
+	raw = protowire.AppendBytes(raw, payload)
+	opts.ProtoReflect().SetUnknown(raw)
+	return opts
+}
+
+// validatedFile builds a proto3 test file importing buf/validate/validate.proto
+func validatedFile(t *testing.T, enums []*descriptorpb.EnumDescriptorProto, messages ...*descriptorpb.DescriptorProto) protoreflect.FileDescriptor {
+	t.Helper()
+	return fileImporting(t, []protoreflect.FileDescriptor{validateFile}, enums, messages...)
+}
+
+// fileImporting builds a proto3 test file in package "test" importing deps
+func fileImporting(t *testing.T, deps []protoreflect.FileDescriptor, enums []*descriptorpb.EnumDescriptorProto, messages ...*descriptorpb.DescriptorProto) protoreflect.FileDescriptor {
+	t.Helper()
+	files := new(protoregistry.Files)
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:        proto.String(t.Name() + ".proto"),
+		Package:     proto.String("test"),
+		Syntax:      proto.String("proto3"),
+		MessageType: messages,
+		EnumType:    enums,
+	}
+	for _, dep := range deps {
+		if err := files.RegisterFile(dep); err != nil {
+			t.Fatalf("register %s: %v", dep.Path(), err)
+		}
+		fdp.Dependency = append(fdp.Dependency, dep.Path())
+	}
+	fd, err := protodesc.NewFile(fdp, files)
+	if err != nil {
+		t.Fatalf("failed to build test descriptor: %v", err)
+	}
+	return fd
+}
+
+func TestProtovalidate_FieldRules(t *testing.T) {
+	fieldRules := func(text string) *descriptorpb.FieldOptions {
+		return withUnknownRules(t, &descriptorpb.FieldOptions{}, rules(t, "FieldRules", text))
+	}
+	statusField := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String("status"),
+		Number:   proto.Int32(5),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     descriptorpb.FieldDescriptorProto_TYPE_ENUM.Enum(),
+		TypeName: proto.String(".test.Status"),
+		Options:  fieldRules(`{"enum": {"defined_only": true, "not_in": [0]}}`),
+	}
+	fd := validatedFile(t,
+		[]*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Status"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("STATUS_UNSPECIFIED"), Number: proto.Int32(0)},
+				{Name: proto.String("ACTIVE"), Number: proto.Int32(1)},
+			},
+		}},
+		testMessage("User", nil,
+			testField("email", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldRules(`{"required": true, "string": {"email": true, "min_len": 3, "max_len": 254}}`)),
+			testField("age", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, fieldRules(`{"int32": {"gte": 18, "lt": 150}}`)),
+			repeated(testField("tags", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldRules(`{"repeated": {"min_items": 1, "max_items": 5, "unique": true, "items": {"string": {"in": ["a", "b"]}}}}`))),
+			testField("code", 4, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldRules(`{"string": {"prefix": "SKU-", "suffix": "-X"}, "cel": [{"id": "even", "expression": "size(this) % 2 == 0"}, {"id": "upper", "message": "must be upper case", "expression": "this == this.upperAscii()"}]}`)),
+			statusField,
+			testField("ignored", 6, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldRules(`{"ignore": "IGNORE_ALWAYS", "string": {"min_len": 1}}`)),
+		),
+	)
+
+	schema, err := NewGenerator().GenerateSchema(fd.Messages().ByName("User"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	data, _ := json.Marshal(schema)
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatal(err)
+	}
+	props := m["properties"].(map[string]interface{})
+
+	assertKeywords(t, props["email"], `{"format":"email","maxLength":254,"minLength":3,"type":"string"}`)
+	assertKeywords(t, props["age"], `{"exclusiveMaximum":150,"minimum":18,"type":"integer"}`)
+	assertKeywords(t, props["tags"], `{"items":{"enum":["a","b"],"type":"string"},"maxItems":5,"minItems":1,"type":"array","uniqueItems":true}`)
+	assertKeywords(t, props["code"], `{"description":"Validation: size(this) % 2 == 0; must be upper case; string.suffix = \"-X\"","pattern":"^SKU-","type":"string"}`)
+	assertKeywords(t, props["status"], `{"enum":["ACTIVE"],"type":"string"}`)
+	assertKeywords(t, props["ignored"], `{"type":"string"}`)
+
+	if required, _ := json.Marshal(m["required"]); string(required) != `["email"]` {
+		t.Errorf("expected protovalidate required field, got %s", required)
+	}
+}
+
+func TestProtovalidate_ExplicitOptionsWin(t *testing.T) {
+	opts := withUnknownRules(t, fieldOpts(
+		ext(jsonschemapb.E_MinLength, int32(5)),
+		ext(jsonschemapb.E_Minimum, float64(0)),
+		ext(jsonschemapb.E_Description, "Display name"),
+		ext(jsonschemapb.E_Required, false),
+	), rules(t, "FieldRules", `{"required": true, "cel": [{"message": "no spaces"}]}`))
+	numberOpts := withUnknownRules(t, fieldOpts(ext(jsonschemapb.E_Minimum, float64(0))),
+		rules(t, "FieldRules", `{"double": {"gt": 1, "lte": 10}}`))
+	stringOpts := withUnknownRules(t, fieldOpts(ext(jsonschemapb.E_MinLength, int32(5))),
+		rules(t, "FieldRules", `{"string": {"min_len": 1}}`))
+
+	fd := validatedFile(t, nil,
+		testMessage("Profile", nil,
+			testField("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, opts),
+			testField("score", 2, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE, numberOpts),
+			testField("nick", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, stringOpts),
+		),
+	)
+
+	schema, err := NewGenerator().GenerateSchema(fd.Messages().ByName("Profile"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	m := mustSchemaMap(t, schema)
+	if _, ok := m["required"]; ok {
+		t.Errorf("explicit required=false must win, got %v", m["required"])
+	}
+	props := m["properties"].(map[string]interface{})
+	assertKeywords(t, props["name"], `{"description":"Display name\n\nValidation: no spaces","minLength":5,"minimum":0,"type":"string"}`)
+	assertKeywords(t, props["score"], `{"maximum":10,"minimum":0,"type":"number"}`)
+	assertKeywords(t, props["nick"], `{"minLength":5,"type":"string"}`)
+}
+
+func TestProtovalidate_LinkedExtensionAndMessageRules(t *testing.T) {
+	fieldExt := dynamicpb.NewExtensionType(validateFile.Extensions().ByName("field"))
+	fieldOptions := &descriptorpb.FieldOptions{}
+	proto.SetExtension(fieldOptions, fieldExt, rules(t, "FieldRules", `{"string": {"uuid": true}}`))
+
+	messageOptions := withUnknownRules(t, msgOpts(ext(jsonschemapb.E_MessageDescription, "An order")),
+		rules(t, "MessageRules", `{"cel": [{"id": "dates", "message": "end must follow start", "expression": "this.end > this.start"}]}`))
+	disabledOptions := withUnknownRules(t, &descriptorpb.MessageOptions{}, rules(t, "MessageRules", `{"disabled": true}`))
+
+	fd := validatedFile(t, nil,
+		testMessage("Order", messageOptions,
+			testField("id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOptions),
+		),
+		testMessage("Draft", disabledOptions,
+			testField("id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING,
+				withUnknownRules(t, &descriptorpb.FieldOptions{}, rules(t, "FieldRules", `{"required": true, "string": {"uuid": true}}`))),
+		),
+	)
+
+	order, err := NewGenerator().GenerateSchema(fd.Messages().ByName("Order"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	if order["description"] != "An order\n\nValidation: end must follow start" {
+		t.Errorf("expected message CEL note in description, got %q", order["description"])
+	}
+	if id := order["properties"].(map[string]interface{})["id"].(Schema); id["format"] != "uuid" {
+		t.Errorf("expected linked extension rules translated, got %v", id)
+	}
+
+	draft, err := NewGenerator().GenerateSchema(fd.Messages().ByName("Draft"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	if _, ok := draft["required"]; ok {
+		t.Error("disabled message must not translate field rules")
+	}
+	if id := draft["properties"].(map[string]interface{})["id"].(Schema); len(id) != 1 {
+		t.Errorf("disabled message must not translate field rules, got %v", id)
+	}
+}
+
+func TestProtovalidate_MalformedRules(t *testing.T) {
+	opts := &descriptorpb.FieldOptions{}
+	raw := protowire.AppendTag(nil, 1159, protowire.BytesType)
+	raw = protowire.AppendBytes(raw, []byte{0x72, 0xff})
+	opts.ProtoReflect().SetUnknown(raw)
+
+	fd := validatedFile(t, nil,
+		testMessage("Broken", nil, testField("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, opts)),
+	)
+	_, err := NewGenerator().GenerateSchema(fd.Messages().ByName("Broken"))
+	if err == nil || !strings.Contains(err.Error(), "test.Broken.name: invalid buf.validate.field option") {
+		t.Errorf("expected malformed rules error, got %v", err)
+	}
+}
+
+// assertKeywords compares a property schema with the expected JSON
+func assertKeywords(t *testing.T, got interface{}, want string) {
+	t.Helper()
+	var expected map[string]interface{}
+	if err := json.Unmarshal([]byte(want), &expected); err != nil {
+		t.Fatalf("bad expectation %s: %v", want, err)
+	}
+	wantJSON, _ := json.Marshal(expected)
+	gotJSON, _ := json.Marshal(got)
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("unexpected schema\n got: %s\nwant: %s", gotJSON, wantJSON)
+	}
+}
@@ -0,0 +1,65 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// applySchemaJSON applies a raw JSON Schema fragment option to a generated
+// schema: by default the fragment is deep-merged into schema, and with the
+// replace option set it replaces schema entirely. The fragment must be a JSON
+// object; anything else is an error rather than being silently dropped.
+func applySchemaJSON(schema Schema, opts proto.Message, fragmentExt, replaceExt protoreflect.ExtensionType) (Schema, error) {
+	if !proto.HasExtension(opts, fragmentExt) {
+		return schema, nil
+	}
+
+	var fragment map[string]interface{}
+	if err := json.Unmarshal([]byte(proto.GetExtension(opts, fragmentExt).(string)), &fragment); err != nil {
+		return nil, err
+	}
+	if fragment == nil {
+		return nil, fmt.Errorf("schema fragment must be a JSON object")
+	}
+
+	if isReplaceSchemaJSON(opts, replaceExt) {
+		return Schema(fragment), nil
+	}
+	deepMerge(schema, fragment)
+	return schema, nil
+}
+
+// deepMerge merges src into dst: nested objects are merged key by key, and
+// every other value (including arrays) in src replaces the one in dst
+func deepMerge(dst, src map[string]interface{}) {
+	for key, value := range src {
+		if srcObj, ok := asObject(value); ok {
+			if dstObj, ok := asObject(dst[key]); ok {
+				deepMerge(dstObj, srcObj)
+				continue
+			}
+		}
+		dst[key] = value
+	}
+}
+
+// isReplaceSchemaJSON reports whether a schema_json option replaces the
+// generated schema rather than being merged into it
+func isReplaceSchemaJSON(opts proto.Message, replaceExt protoreflect.ExtensionType) bool {
+	return proto.HasExtension(opts, replaceExt) && proto.GetExtension(opts, replaceExt).(bool)
+}
+
+// asObject returns value as a plain map if it is a JSON object, whether held as
+// a Schema or as a decoded map[string]interface{}
+func asObject(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case Schema:
+		return v, true
+	case map[string]interface{}:
+		return v, true
+	}
+	return nil, false
+}
@@ -0,0 +1,122 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+func TestSchemaJSON_FieldMerge(t *testing.T) {
+	fd := testFile(t,
+		testMessage("Doc", nil,
+			repeated(testField("tags", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(
+				ext(jsonschemapb.E_Description, "Tags"),
+				ext(jsonschemapb.E_SchemaJson, `{"uniqueItems": true, "items": {"minLength": 1}, "description": "Unique tags"}`),
+			))),
+		),
+	)
+
+	schema, err := NewGenerator().GenerateSchema(fd.Messages().ByName("Doc"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	tags := mustSchemaMap(t, schema)["properties"].(map[string]interface{})["tags"].(map[string]interface{})
+	if tags["uniqueItems"] != true || tags["description"] != "Unique tags" {
+		t.Errorf("expected merged uniqueItems and overridden description, got %v", tags)
+	}
+	items := tags["items"].(map[string]interface{})
+	if items["type"] != "string" || items["minLength"] != float64(1) {
+		t.Errorf("expected items deep-merged with generated type, got %v", items)
+	}
+}
+
+func TestSchemaJSON_FieldReplace(t *testing.T) {
+	fd := testFile(t,
+		testMessage("Doc", nil,
+			testField("amount", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(
+				ext(jsonschemapb.E_Format, "email"),
+				ext(jsonschemapb.E_SchemaJson, `{"type": "string", "pattern": "^[0-9]+(\\.[0-9]{2})?$"}`),
+				ext(jsonschemapb.E_SchemaJsonReplace, true),
+			)),
+		),
+	)
+
+	schema, err := NewGenerator().GenerateSchema(fd.Messages().ByName("Doc"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	amount := mustSchemaMap(t, schema)["properties"].(map[string]interface{})["amount"].(map[string]interface{})
+	if _, ok := amount["format"]; ok || len(amount) != 2 {
+		t.Errorf("expected generated schema fully replaced, got %v", amount)
+	}
+}
+
+func TestSchemaJSON_MessageMergeOrdered(t *testing.T) {
+	fd := testFile(t,
+		testMessage("Doc", msgOpts(ext(jsonschemapb.E_MessageSchemaJson,
+			`{"$comment": "managed", "properties": {"title": {"maxLength": 80}, "extra": {"type": "null"}}, "required": ["title"]}`)),
+			testField("title", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+			testField("body", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+		),
+	)
+
+	ordered, err := NewGeneratorWithOptions(true).GenerateOrderedSchema(fd.Messages().ByName("Doc"))
+	if err != nil {
+		t.Fatalf("GenerateOrderedSchema failed: %v", err)
+	}
+	data, err := json.Marshal(ordered)
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+	want := `{"type":"object","title":"Doc","properties":{"title":{"maxLength":80,"type":"string"},"body":{"type":"string"},"extra":{"type":"null"}},"required":["title"],"$comment":"managed"}`
+	if string(data) != want {
+		t.Errorf("unexpected ordered schema\n got: %s\nwant: %s", data, want)
+	}
+}
+
+func TestSchemaJSON_MessageReplace(t *testing.T) {
+	fd := testFile(t,
+		testMessage("Doc", msgOpts(
+			ext(jsonschemapb.E_MessageSchemaJson, `{"type": "string"}`),
+			ext(jsonschemapb.E_MessageSchemaJsonReplace, true),
+		),
+			testField("title", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+		),
+	)
+
+	schema, err := NewGenerator().GenerateSchema(fd.Messages().ByName("Doc"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	if len(schema) != 1 || schema["type"] != "string" {
+		t.Errorf("expected message schema replaced, got %v", schema)
+	}
+}
+
+func TestSchemaJSON_MalformedIsError(t *testing.T) {
+	for _, fragment := range []string{`{"type": `, `["not", "object"]`, `null`} {
+		fieldFile := testFile(t,
+			testMessage("Doc", nil,
+				testField("title", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(ext(jsonschemapb.E_SchemaJson, fragment))),
+			),
+		)
+		_, err := NewGenerator().GenerateSchema(fieldFile.Messages().ByName("Doc"))
+		if err == nil || !strings.Contains(err.Error(), "test.Doc.title: invalid schema_json") {
+			t.Errorf("expected field error for %s, got %v", fragment, err)
+		}
+
+		messageFile := testFile(t,
+			testMessage("Doc", msgOpts(ext(jsonschemapb.E_MessageSchemaJson, fragment)),
+				testField("title", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+			),
+		)
+		_, err = NewGenerator().GenerateSchema(messageFile.Messages().ByName("Doc"))
+		if err == nil || !strings.Contains(err.Error(), "test.Doc: invalid message_schema_json") {
+			t.Errorf("expected message error for %s, got %v", fragment, err)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package jsonschema
+
+import (
+	"fmt"
+	"regexp"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+// ToolProvider selects the tool definition format of GenerateTools
+type ToolProvider string
+
+// Supported tool providers
+const (
+	ToolProviderAnthropic ToolProvider = "anthropic"
+	ToolProviderOpenAI    ToolProvider = "openai"
+	ToolProviderGemini    ToolProvider = "gemini"
+)
+
+// toolNamePatterns are the tool names each provider accepts
+var toolNamePatterns = map[ToolProvider]*regexp.Regexp{
+	ToolProviderAnthropic: regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`),
+	ToolProviderOpenAI:    regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`),
+	ToolProviderGemini:    regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_.:-]{0,63}$`),
+}
+
+// IsValid reports whether p is one of the supported tool providers
+func (p ToolProvider) IsValid() bool {
+	_, ok := toolNamePatterns[p]
+	return ok
+}
+
+// AnthropicTool is a tool definition of the Anthropic Messages API
+type AnthropicTool struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	InputSchema Schema `json:"input_schema"`
+}
+
+// OpenAITool is a function tool definition of the OpenAI Chat Completions API
+type OpenAITool struct {
+	Type     string         `json:"type"`
+	Function OpenAIFunction `json:"function"`
+}
+
+// OpenAIFunction is the function of an OpenAITool
+type OpenAIFunction struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Parameters  Schema `json:"parameters"`
+	Strict      bool   `json:"strict,omitempty"`
+}
+
+// GeminiTool is a Gemini tool holding function declarations
+type GeminiTool struct {
+	FunctionDeclarations []GeminiFunctionDeclaration `json:"functionDeclarations"`
+}
+
+// GeminiFunctionDeclaration is a function declaration of a GeminiTool
+type GeminiFunctionDeclaration struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Parameters  Schema `json:"parameters"`
+}
+
+// GenerateTools generates the tool definitions of files in the format of
+// provider: a []AnthropicTool, a []OpenAITool or a GeminiTool, or nil when
+// files define no tools. Tools come from the unary methods of services, as
+// with GenerateMCPTools but without generating the response messages, and
+// from messages with the message_tool_name option, which take the message
+// itself as input and are described by their description. Tool names must be
+// valid for provider. OpenAI functions are marked strict under the
+// openai_strict profile. The gemini provider always applies the gemini
+// profile, without a dialect, and rejects any other profile.
+func (g *Generator) GenerateTools(provider ToolProvider, files ...protoreflect.FileDescriptor) (interface{}, error) {
+	if !provider.IsValid() {
+		return nil, fmt.Errorf("unknown tool provider: %s", provider)
+	}
+	gen := *g
+	if provider == ToolProviderGemini {
+		// function declarations only accept the gemini subset, without $schema
+		if gen.profile != "" && gen.profile != ProfileGemini {
+			return nil, fmt.Errorf("provider %s cannot be combined with the %s profile", provider, gen.profile)
+		}
+		gen.profile = ProfileGemini
+		gen.dialect = ""
+	}
+
+	// only the request side is needed, so response messages are neither
+	// generated nor checked against the profile
+	tools, err := gen.methodTools(files, gen.mcpToolInput)
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	for _, tool := range tools {
+		names[tool.Name] = true
+	}
+	for _, file := range files {
+		messageTools, err := gen.messageTools(file.Messages(), names)
+		if err != nil {
+			return nil, err
+		}
+		tools = append(tools, messageTools...)
+	}
+	if len(tools) == 0 {
+		return nil, nil
+	}
+
+	for _, tool := range tools {
+		if pattern := toolNamePatterns[provider]; !pattern.MatchString(tool.Name) {
+			return nil, fmt.Errorf("tool name %q is not valid for %s: must match %s", tool.Name, provider, pattern)
+		}
+	}
+
+	switch provider {
+	case ToolProviderOpenAI:
+		definitions := make([]OpenAITool, len(tools))
+		for i, tool := range tools {
+			definitions[i] = OpenAITool{Type: "function", Function: OpenAIFunction{
+				Name:        tool.Name,
+				Description: tool.Description,
+				Parameters:  tool.InputSchema,
+				Strict:      g.profile == ProfileOpenAIStrict,
+			}}
+		}
+		return definitions, nil
+	case ToolProviderGemini:
+		definitions := GeminiTool{FunctionDeclarations: make([]GeminiFunctionDeclaration, len(tools))}
+		for i, tool := range tools {
+			definitions.FunctionDeclarations[i] = GeminiFunctionDeclaration{
+				Name:        tool.Name,
+				Description: tool.Description,
+				Parameters:  tool.InputSchema,
+			}
+		}
+		return definitions, nil
+	default:
+		definitions := make([]AnthropicTool, len(tools))
+		for i, tool := range tools {
+			definitions[i] = AnthropicTool{Name: tool.Name, Description: tool.Description, InputSchema: tool.InputSchema}
+		}
+		return definitions, nil
+	}
+}
+
+// messageTools generates the tools of messages with the message_tool_name
+// option, descending into nested messages, and records their names in names
+func (g *Generator) messageTools(messages protoreflect.MessageDescriptors, names map[string]bool) ([]MCPTool, error) {
+	var tools []MCPTool
+	for i := 0; i < messages.Len(); i++ {
+		md := messages.Get(i)
+		opts, _ := md.Options().(*descriptorpb.MessageOptions)
+		if name := proto.GetExtension(opts, jsonschemapb.E_MessageToolName).(string); name != "" {
+			if names[name] {
+				return nil, fmt.Errorf("%s: tool name %q already used", md.FullName(), name)
+			}
+			names[name] = true
+
+			tool, err := g.messageTool(md, name)
+			if err != nil {
+				return nil, err
+			}
+			tools = append(tools, tool)
+		}
+
+		nested, err := g.messageTools(md.Messages(), names)
+		if err != nil {
+			return nil, err
+		}
+		tools = append(tools, nested...)
+	}
+	return tools, nil
+}
+
+// messageTool generates the tool taking md as input. It is described by the
+// message description, or else the message's leading comment.
+func (g *Generator) messageTool(md protoreflect.MessageDescriptor, name string) (MCPTool, error) {
+	input, err := g.GenerateSchema(md)
+	if err != nil {
+		return MCPTool{}, err
+	}
+	if input == nil {
+		input = Schema{"type": "object"}
+	}
+
+	description, _ := input["description"].(string)
+	if description == "" {
+		description = leadingComment(md)
+	}
+	return MCPTool{Name: name, Description: description, InputSchema: input}, nil
+}
@@ -0,0 +1,180 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+// toolsFile builds a Weather service with one tool method plus a message tool
+func toolsFile(t *testing.T, methodTool, messageTool string) protoreflect.FileDescriptor {
+	t.Helper()
+	messages := []*descriptorpb.DescriptorProto{
+		testMessage("ForecastRequest", nil, testField("city", 1, tString, nil)),
+		testMessage("Forecast", nil, testField("summary", 1, tString, nil)),
+		testMessage("CityLookup", msgOpts(
+			ext(jsonschemapb.E_MessageToolName, messageTool),
+			ext(jsonschemapb.E_MessageDescription, "Look up a city."),
+		), testField("name", 1, tString, nil)),
+	}
+	return serviceFile(t, messages, []*descriptorpb.MethodDescriptorProto{
+		toolMethod("GetForecast", ".test.ForecastRequest", ".test.Forecast", methodOpts(ext(jsonschemapb.E_ToolName, methodTool))),
+	}, map[int32]string{0: " Get the forecast.\n"})
+}
+
+func marshalTools(t *testing.T, g *Generator, provider ToolProvider, fd protoreflect.FileDescriptor) string {
+	t.Helper()
+	tools, err := g.GenerateTools(provider, fd)
+	if err != nil {
+		t.Fatalf("GenerateTools failed: %v", err)
+	}
+	data, err := json.Marshal(tools)
+	if err != nil {
+		t.Fatalf("failed to marshal tools: %v", err)
+	}
+	return string(data)
+}
+
+func TestGenerateTools_Providers(t *testing.T) {
+	fd := toolsFile(t, "get_forecast", "lookup_city")
+	forecast := `{"properties":{"city":{"type":"string"}},"title":"ForecastRequest","type":"object"}`
+	lookup := `{"description":"Look up a city.","properties":{"name":{"type":"string"}},"title":"CityLookup","type":"object"}`
+
+	for _, tc := range []struct {
+		provider ToolProvider
+		want     string
+	}{
+		{ToolProviderAnthropic, `[{"name":"get_forecast","description":"Get the forecast.","input_schema":` + forecast + `},` +
+			`{"name":"lookup_city","description":"Look up a city.","input_schema":` + lookup + `}]`},
+		{ToolProviderOpenAI, `[{"type":"function","function":{"name":"get_forecast","description":"Get the forecast.","parameters":` + forecast + `}},` +
+			`{"type":"function","function":{"name":"lookup_city","description":"Look up a city.","parameters":` + lookup + `}}]`},
+		{ToolProviderGemini, `{"functionDeclarations":[{"name":"get_forecast","description":"Get the forecast.","parameters":` +
+			`{"properties":{"city":{"type":"STRING"}},"title":"ForecastRequest","type":"OBJECT"}},` +
+			`{"name":"lookup_city","description":"Look up a city.","parameters":` +
+			`{"description":"Look up a city.","properties":{"name":{"type":"STRING"}},"title":"CityLookup","type":"OBJECT"}}]}`},
+	} {
+		if got := marshalTools(t, NewGenerator(), tc.provider, fd); got != tc.want {
+			t.Errorf("%s: unexpected tools\n got: %s\nwant: %s", tc.provider, got, tc.want)
+		}
+	}
+}
+
+func TestGenerateTools_OpenAIStrict(t *testing.T) {
+	g := NewGenerator()
+	g.SetProfile(ProfileOpenAIStrict)
+	tools, err := g.GenerateTools(ToolProviderOpenAI, toolsFile(t, "get_forecast", "lookup_city"))
+	if err != nil {
+		t.Fatalf("GenerateTools failed: %v", err)
+	}
+	for _, tool := range tools.([]OpenAITool) {
+		if !tool.Function.Strict || tool.Function.Parameters["additionalProperties"] != false {
+			t.Errorf("expected strict function with strict parameters, got %+v", tool.Function)
+		}
+	}
+}
+
+func TestGenerateTools_IgnoresResponses(t *testing.T) {
+	entry := testMessage("LabelsEntry", &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+		testField("key", 1, tString, nil),
+		testField("value", 2, tString, nil),
+	)
+	report := testMessage("Report", msgOpts(ext(jsonschemapb.E_DependentRequired, []*jsonschemapb.DependentRequired{
+		{Field: "labels", Requires: []string{"missing"}},
+	})), repeated(testMessageField("labels", 1, ".test.Report.LabelsEntry", nil)))
+	report.NestedType = []*descriptorpb.DescriptorProto{entry}
+	fd := serviceFile(t, []*descriptorpb.DescriptorProto{
+		testMessage("ReportRequest", nil, testField("city", 1, tString, nil)),
+		report,
+	}, []*descriptorpb.MethodDescriptorProto{
+		toolMethod("GetReport", ".test.ReportRequest", ".test.Report", nil),
+	}, nil)
+
+	g := NewGenerator()
+	g.SetProfile(ProfileOpenAIStrict)
+	if _, err := g.GenerateMCPTools(fd); err == nil {
+		t.Fatal("expected GenerateMCPTools to reject the response message")
+	}
+	tools, err := g.GenerateTools(ToolProviderOpenAI, fd)
+	if err != nil {
+		t.Fatalf("GenerateTools failed: %v", err)
+	}
+	if got := tools.([]OpenAITool); len(got) != 1 || got[0].Function.Name != "GetReport" {
+		t.Errorf("unexpected tools %+v", got)
+	}
+}
+
+func TestGenerateTools_NestedMessageTool(t *testing.T) {
+	outer := testMessage("Atlas", nil, testField("name", 1, tString, nil))
+	outer.NestedType = []*descriptorpb.DescriptorProto{
+		testMessage("Search", msgOpts(ext(jsonschemapb.E_MessageToolName, "search_atlas")), testField("query", 1, tString, nil)),
+	}
+	fd := testFile(t, outer)
+
+	tools, err := NewGenerator().GenerateTools(ToolProviderAnthropic, fd)
+	if err != nil {
+		t.Fatalf("GenerateTools failed: %v", err)
+	}
+	if got, _ := tools.([]AnthropicTool); len(got) != 1 || got[0].Name != "search_atlas" || got[0].InputSchema["title"] != "Search" {
+		t.Errorf("expected a tool for the nested message, got %+v", tools)
+	}
+}
+
+func TestGenerateTools_GeminiProfile(t *testing.T) {
+	fd := serviceFile(t, []*descriptorpb.DescriptorProto{
+		testMessage("ScheduleRequest", nil, testMessageField("at", 1, ".google.protobuf.Timestamp", nil)),
+		testMessage("Schedule", nil),
+	}, []*descriptorpb.MethodDescriptorProto{
+		toolMethod("Schedule", ".test.ScheduleRequest", ".test.Schedule", nil),
+	}, nil)
+
+	g := NewGenerator()
+	g.SetDialect(Dialect202012)
+	got := marshalTools(t, g, ToolProviderGemini, fd)
+	want := `{"functionDeclarations":[{"name":"Schedule","parameters":` +
+		`{"properties":{"at":{"description":"RFC3339 timestamp string","format":"date-time","type":"STRING"}},"title":"ScheduleRequest","type":"OBJECT"}}]}`
+	if got != want {
+		t.Errorf("unexpected tools\n got: %s\nwant: %s", got, want)
+	}
+
+	g.SetProfile(ProfileOpenAIStrict)
+	if _, err := g.GenerateTools(ToolProviderGemini, fd); err == nil || !strings.Contains(err.Error(), "cannot be combined with the openai_strict profile") {
+		t.Errorf("expected profile mismatch error, got %v", err)
+	}
+}
+
+func TestGenerateTools_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		name                    string
+		provider                ToolProvider
+		methodTool, messageTool string
+		want                    string
+	}{
+		{"duplicate", ToolProviderAnthropic, "lookup", "lookup", `test.CityLookup: tool name "lookup" already used`},
+		{"invalid name", ToolProviderOpenAI, "weather.get", "lookup", `tool name "weather.get" is not valid for openai`},
+		{"unknown provider", "mistral", "get", "lookup", "unknown tool provider: mistral"},
+	} {
+		_, err := NewGenerator().GenerateTools(tc.provider, toolsFile(t, tc.methodTool, tc.messageTool))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.want, err)
+		}
+	}
+
+	// Gemini accepts dotted names
+	if _, err := NewGenerator().GenerateTools(ToolProviderGemini, toolsFile(t, "weather.get", "lookup")); err != nil {
+		t.Errorf("expected dotted name to be valid for gemini, got %v", err)
+	}
+}
+
+func TestGenerateTools_None(t *testing.T) {
+	fd := testFile(t, testMessage("Book", nil, testField("title", 1, tString, nil)))
+	tools, err := NewGenerator().GenerateTools(ToolProviderAnthropic, fd)
+	if err != nil || tools != nil {
+		t.Errorf("expected no tools, got %v, %v", tools, err)
+	}
+}
@@ -0,0 +1,88 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// TypeMapper returns the schema of a field whose message type it is
+// registered for. It must return a fresh Schema on every call, since the
+// generator applies field options to the result in place.
+type TypeMapper func(field protoreflect.FieldDescriptor) Schema
+
+// RegisterTypeMapper maps the message type fullName (e.g. "acme.types.Decimal")
+// to mapper. Fields of that type (or repeated fields of it, per item) take
+// their schema from mapper instead of the built-in mapping; field options are
+// still applied on top. Registering a well-known type such as
+// google.protobuf.Timestamp overrides its built-in schema.
+func (g *Generator) RegisterTypeMapper(fullName protoreflect.FullName, mapper TypeMapper) {
+	if g.typeMappers == nil {
+		g.typeMappers = make(map[protoreflect.FullName]TypeMapper)
+	}
+	g.typeMappers[fullName] = mapper
+}
+
+// typeMapper returns the mapper registered for a message-typed field, if any
+func (g *Generator) typeMapper(field protoreflect.FieldDescriptor) (TypeMapper, bool) {
+	if field.Kind() != protoreflect.MessageKind || field.IsMap() {
+		return nil, false
+	}
+	mapper, ok := g.typeMappers[field.Message().FullName()]
+	return mapper, ok
+}
+
+// StaticTypeMapper returns a TypeMapper that yields a deep copy of schema for
+// every field, for mappings that do not depend on the field itself
+func StaticTypeMapper(schema Schema) TypeMapper {
+	return func(protoreflect.FieldDescriptor) Schema {
+		return Schema(deepCopy(map[string]interface{}(schema)).(map[string]interface{}))
+	}
+}
+
+// RegisterTypeMap registers a StaticTypeMapper for every entry of a JSON type
+// map read from r: an object keyed by message full name whose values are the
+// JSON Schema objects to emit, e.g.
+//
+//	{"acme.types.Decimal": {"type": "string", "pattern": "^-?[0-9]+(\\.[0-9]+)?$"}}
+func (g *Generator) RegisterTypeMap(r io.Reader) error {
+	var typeMap map[string]map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&typeMap); err != nil {
+		return fmt.Errorf("invalid type map: %w", err)
+	}
+	for fullName, schema := range typeMap {
+		if !protoreflect.FullName(fullName).IsValid() {
+			return fmt.Errorf("invalid type map: %q is not a message full name", fullName)
+		}
+		if schema == nil {
+			return fmt.Errorf("invalid type map: schema for %s must be a JSON object", fullName)
+		}
+		g.RegisterTypeMapper(protoreflect.FullName(fullName), StaticTypeMapper(schema))
+	}
+	return nil
+}
+
+// deepCopy copies decoded JSON objects and arrays so the copy can be mutated
+// without affecting the original
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case Schema:
+		return deepCopy(map[string]interface{}(v))
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = deepCopy(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = deepCopy(item)
+		}
+		return copied
+	default:
+		return v
+	}
+}
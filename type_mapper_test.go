@@ -0,0 +1,122 @@
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+func decimalFile(t *testing.T) protoreflect.FileDescriptor {
+	t.Helper()
+	return testFile(t,
+		testMessage("Decimal", nil,
+			testField("value", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+		),
+		testMessage("Invoice", nil,
+			testMessageField("total", 1, ".test.Decimal", fieldOpts(ext(jsonschemapb.E_Description, "Invoice total"))),
+			repeated(testMessageField("lines", 2, ".test.Decimal", nil)),
+			testMessageField("created", 3, ".google.protobuf.Timestamp", nil),
+		),
+	)
+}
+
+func TestTypeMapper_Registered(t *testing.T) {
+	fd := decimalFile(t)
+	g := NewGenerator()
+	g.RegisterTypeMapper("test.Decimal", func(field protoreflect.FieldDescriptor) Schema {
+		return Schema{"type": "string", "pattern": `^-?\d+(\.\d+)?$`}
+	})
+	g.RegisterTypeMapper("google.protobuf.Timestamp", func(field protoreflect.FieldDescriptor) Schema {
+		return Schema{"type": "string", "format": "date-time"}
+	})
+
+	schema, err := g.GenerateSchema(fd.Messages().ByName("Invoice"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	props := mustSchemaMap(t, schema)["properties"].(map[string]interface{})
+
+	total := props["total"].(map[string]interface{})
+	if total["type"] != "string" || total["pattern"] == nil || total["description"] != "Invoice total" {
+		t.Errorf("expected mapped schema with field options applied, got %v", total)
+	}
+	items := props["lines"].(map[string]interface{})["items"].(map[string]interface{})
+	if items["type"] != "string" || items["pattern"] == nil {
+		t.Errorf("expected mapped schema for repeated items, got %v", items)
+	}
+	created := props["created"].(map[string]interface{})
+	if _, ok := created["oneOf"]; ok || created["format"] != "date-time" {
+		t.Errorf("expected mapper to override built-in Timestamp schema, got %v", created)
+	}
+}
+
+func TestTypeMapper_PartialTreatsMappedTypesAsValues(t *testing.T) {
+	fd := testFile(t,
+		testMessage("Decimal", nil,
+			testField("value", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+		),
+		testMessage("Product", nil,
+			testMessageField("price", 1, ".test.Decimal", nil),
+		),
+		testMessage("UpdateProductRequest", msgOpts(ext(jsonschemapb.E_Partial, true)),
+			testMessageField("product", 1, ".test.Product", nil),
+			testMessageField("update_mask", 2, ".google.protobuf.FieldMask", nil),
+		),
+	)
+	g := NewGenerator()
+	g.RegisterTypeMapper("test.Decimal", StaticTypeMapper(Schema{"type": "string"}))
+
+	schema, err := g.GenerateSchema(fd.Messages().ByName("UpdateProductRequest"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	props := mustSchemaMap(t, schema)["properties"].(map[string]interface{})
+	product := props["product"].(map[string]interface{})["properties"].(map[string]interface{})
+	if price := product["price"].(map[string]interface{}); len(price) != 1 || price["type"] != "string" {
+		t.Errorf("mapped type must not be expanded, got %v", price)
+	}
+	if pattern := props["updateMask"].(map[string]interface{})["pattern"]; pattern != "^(?:price)(?:,(?:price))*$" {
+		t.Errorf("mask paths must not descend into mapped types, got %v", pattern)
+	}
+}
+
+func TestRegisterTypeMap(t *testing.T) {
+	fd := decimalFile(t)
+	g := NewGenerator()
+	err := g.RegisterTypeMap(strings.NewReader(`{"test.Decimal": {"type": "string", "examples": ["1.50"]}}`))
+	if err != nil {
+		t.Fatalf("RegisterTypeMap failed: %v", err)
+	}
+
+	schema, err := g.GenerateSchema(fd.Messages().ByName("Invoice"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	props := mustSchemaMap(t, schema)["properties"].(map[string]interface{})
+	total := props["total"].(map[string]interface{})
+	items := props["lines"].(map[string]interface{})["items"].(map[string]interface{})
+	if total["type"] != "string" || items["type"] != "string" {
+		t.Fatalf("expected declarative mapping, got %v and %v", total, items)
+	}
+	if _, ok := items["description"]; ok {
+		t.Error("field options applied to one mapped schema must not leak into another")
+	}
+}
+
+func TestRegisterTypeMap_Invalid(t *testing.T) {
+	for input, wantErr := range map[string]string{
+		`{"test.Decimal": `:          "invalid type map",
+		`{"not a name": {}}`:         `"not a name" is not a message full name`,
+		`{"test.Decimal": null}`:     "schema for test.Decimal must be a JSON object",
+		`{"test.Decimal": "string"}`: "invalid type map",
+	} {
+		err := NewGenerator().RegisterTypeMap(strings.NewReader(input))
+		if err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("RegisterTypeMap(%s): expected error containing %q, got %v", input, wantErr, err)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+// vendorExtensionPrefix is the prefix every vendor extension keyword must carry
+const vendorExtensionPrefix = "x-"
+
+// applyVendorExtensions emits the vendor extension option ext of opts as x-*
+// keywords on schema. Keys must start with "x-" and values must be valid JSON;
+// owner names the descriptor the option is declared on, for error messages.
+func applyVendorExtensions(schema Schema, owner protoreflect.FullName, opts proto.Message, ext protoreflect.ExtensionType) error {
+	if !proto.HasExtension(opts, ext) {
+		return nil
+	}
+
+	option := ext.TypeDescriptor().Name()
+	for _, vendor := range proto.GetExtension(opts, ext).([]*jsonschemapb.VendorExtension) {
+		key := vendor.GetKey()
+		if !strings.HasPrefix(key, vendorExtensionPrefix) || len(key) == len(vendorExtensionPrefix) {
+			return fmt.Errorf("%s: %s key %q must start with %q", owner, option, key, vendorExtensionPrefix)
+		}
+		var value interface{}
+		if err := json.Unmarshal([]byte(vendor.GetValue()), &value); err != nil {
+			return fmt.Errorf("%s: %s %q has invalid JSON value: %w", owner, option, key, err)
+		}
+		schema[key] = value
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	jsonschemapb "github.com/sunerpy/protoc-gen-jsonschema/mcp/jsonschema"
+)
+
+func vendor(key, value string) *jsonschemapb.VendorExtension {
+	return &jsonschemapb.VendorExtension{Key: key, Value: value}
+}
+
+func TestVendorExtensions_AllLevels(t *testing.T) {
+	enumOpts := &descriptorpb.EnumOptions{}
+	proto.SetExtension(enumOpts, jsonschemapb.E_EnumVendorExtension, []*jsonschemapb.VendorExtension{vendor("x-ui-widget", `"radio"`)})
+	fd := testFileWithEnums(t,
+		[]*descriptorpb.EnumDescriptorProto{{
+			Name:    proto.String("Role"),
+			Options: enumOpts,
+			Value:   []*descriptorpb.EnumValueDescriptorProto{{Name: proto.String("ROLE_UNSPECIFIED"), Number: proto.Int32(0)}},
+		}},
+		testMessage("Account", msgOpts(ext(jsonschemapb.E_MessageVendorExtension, []*jsonschemapb.VendorExtension{
+			vendor("x-ui-group", `{"label": "Account"}`),
+		})),
+			testField("password", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(ext(jsonschemapb.E_VendorExtension, []*jsonschemapb.VendorExtension{
+				vendor("x-sensitive", "true"),
+				vendor("x-ui-widget", `"password"`),
+			}))),
+			repeated(&descriptorpb.FieldDescriptorProto{
+				Name:     proto.String("roles"),
+				Number:   proto.Int32(2),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_ENUM.Enum(),
+				TypeName: proto.String(".test.Role"),
+			}),
+		),
+	)
+
+	schema, err := NewGenerator().GenerateSchema(fd.Messages().ByName("Account"))
+	if err != nil {
+		t.Fatalf("GenerateSchema failed: %v", err)
+	}
+	m := mustSchemaMap(t, schema)
+	if group, ok := m["x-ui-group"].(map[string]interface{}); !ok || group["label"] != "Account" {
+		t.Errorf("expected message x-ui-group, got %v", m["x-ui-group"])
+	}
+	props := m["properties"].(map[string]interface{})
+	password := props["password"].(map[string]interface{})
+	if password["x-sensitive"] != true || password["x-ui-widget"] != "password" {
+		t.Errorf("expected field vendor extensions, got %v", password)
+	}
+	items := props["roles"].(map[string]interface{})["items"].(map[string]interface{})
+	if items["x-ui-widget"] != "radio" {
+		t.Errorf("expected enum vendor extension on enum schema, got %v", items)
+	}
+}
+
+func TestVendorExtensions_OrderedSchema(t *testing.T) {
+	fd := testFile(t,
+		testMessage("Account", msgOpts(ext(jsonschemapb.E_MessageVendorExtension, []*jsonschemapb.VendorExtension{
+			vendor("x-ui-order", `["password"]`),
+		})),
+			testField("password", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(ext(jsonschemapb.E_VendorExtension, []*jsonschemapb.VendorExtension{
+				vendor("x-sensitive", "true"),
+			}))),
+		),
+	)
+
+	ordered, err := NewGeneratorWithOptions(true).GenerateOrderedSchema(fd.Messages().ByName("Account"))
+	if err != nil {
+		t.Fatalf("GenerateOrderedSchema failed: %v", err)
+	}
+	data, err := json.Marshal(ordered)
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+	want := `{"type":"object","title":"Account","properties":{"password":{"type":"string","x-sensitive":true}},"x-ui-order":["password"]}`
+	if string(data) != want {
+		t.Errorf("unexpected ordered schema\n got: %s\nwant: %s", data, want)
+	}
+}
+
+func TestVendorExtensions_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		extension *jsonschemapb.VendorExtension
+		wantErr   string
+	}{
+		{"missing prefix", vendor("sensitive", "true"), `test.Account.password: vendor_extension key "sensitive" must start with "x-"`},
+		{"bare prefix", vendor("x-", "true"), `key "x-" must start with "x-"`},
+		{"invalid JSON", vendor("x-ui-widget", "password"), `vendor_extension "x-ui-widget" has invalid JSON value`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fd := testFile(t,
+				testMessage("Account", nil,
+					testField("password", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, fieldOpts(ext(jsonschemapb.E_VendorExtension, []*jsonschemapb.VendorExtension{tt.extension}))),
+				),
+			)
+			_, err := NewGenerator().GenerateSchema(fd.Messages().ByName("Account"))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}